@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"encoding/json"
+)
+
+// batch admin call modes
+const (
+	BatchModeRun    = "run"
+	BatchModeDryRun = "dryrun"
+)
+
+// BatchSpec items or swaps query of batch admin call,
+// params of batch admin call are [method, operation, mode, spec in json].
+type BatchSpec struct {
+	Items [][]string  `json:"items,omitempty"` // params of each item following the operation
+	Query *BatchQuery `json:"query,omitempty"`
+}
+
+// BatchQuery query swaps to process in batch admin call
+type BatchQuery struct {
+	PairID   string `json:"pairid"`
+	Status   string `json:"status"` // comma separated status numbers
+	MinValue string `json:"minvalue,omitempty"`
+	MaxValue string `json:"maxvalue,omitempty"`
+	Memo     string `json:"memo,omitempty"` // memo of manual operation
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// BatchResult result of batch admin call
+type BatchResult struct {
+	Method    string             `json:"method"`
+	Operation string             `json:"operation"`
+	DryRun    bool               `json:"dryrun"`
+	Total     int                `json:"total"`
+	Success   int                `json:"success"`
+	Failed    int                `json:"failed"`
+	Items     []*BatchItemResult `json:"items"`
+}
+
+// BatchItemResult result of one item in batch admin call
+type BatchItemResult struct {
+	Params []string `json:"params"`
+	Result string   `json:"result,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// EncodeBatchSpec encode batch spec
+func EncodeBatchSpec(spec *BatchSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DecodeBatchSpec decode batch spec
+func DecodeBatchSpec(data string) (*BatchSpec, error) {
+	var spec BatchSpec
+	err := json.Unmarshal([]byte(data), &spec)
+	if err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
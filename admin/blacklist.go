@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"encoding/json"
+)
+
+// BlacklistEntry blacklist entry of blacklist add and import admin call,
+// params of blacklist import admin call are [import, entries in json].
+type BlacklistEntry struct {
+	Address  string `json:"address"`
+	PairID   string `json:"pairid"` // 'all' means all pairs
+	Reason   string `json:"reason,omitempty"`
+	Source   string `json:"source,omitempty"`
+	ExpireAt int64  `json:"expireat,omitempty"` // unix seconds, 0 means never expire
+}
+
+// EncodeBlacklistEntries encode blacklist entries
+func EncodeBlacklistEntries(entries []*BlacklistEntry) (string, error) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DecodeBlacklistEntries decode blacklist entries
+func DecodeBlacklistEntries(data string) ([]*BlacklistEntry, error) {
+	var entries []*BlacklistEntry
+	err := json.Unmarshal([]byte(data), &entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -102,6 +102,16 @@ func decodeCallArgs(data []byte) (*CallArgs, error) {
 
 // VerifyTransaction get sender
 func VerifyTransaction(tx *types.Transaction) (*common.Address, *CallArgs, error) {
+	return verifyTransaction(tx, true)
+}
+
+// VerifyTransactionWithoutExpire get sender and ignore expire checking
+// (used to verify signed message which is published for a long time)
+func VerifyTransactionWithoutExpire(tx *types.Transaction) (*common.Address, *CallArgs, error) {
+	return verifyTransaction(tx, false)
+}
+
+func verifyTransaction(tx *types.Transaction, checkExpire bool) (*common.Address, *CallArgs, error) {
 	if tx.To() == nil || *tx.To() != adminToAddr {
 		return nil, nil, errors.New("wrong admin tx to address")
 	}
@@ -111,7 +121,7 @@ func VerifyTransaction(tx *types.Transaction) (*common.Address, *CallArgs, error
 	}
 	timestamp := args.Timestamp
 	now := time.Now().Unix()
-	if now-timestamp > maxExpireSeconds {
+	if checkExpire && now-timestamp > maxExpireSeconds {
 		return nil, nil, errors.New("expired admin tx timestamp")
 	}
 	if now+maxFutureSeconds < timestamp {
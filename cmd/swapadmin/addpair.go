@@ -13,9 +13,11 @@ var (
 		Action:    addpair,
 		Name:      "addpair",
 		Usage:     "add token pair",
-		ArgsUsage: "<configFile>",
+		ArgsUsage: "<configFile> [trustLimit]",
 		Description: `
-add token pair dynamically through config file
+add token pair dynamically through config file,
+if trustLimit is specified, also create trustline of
+dcrm address for ripple non native pair.
 `,
 		Flags: commonAdminFlags,
 	}
@@ -24,7 +26,7 @@ add token pair dynamically through config file
 func addpair(ctx *cli.Context) error {
 	utils.SetLogger(ctx)
 	method := "addpair"
-	if ctx.NArg() != 1 {
+	if !(ctx.NArg() == 1 || ctx.NArg() == 2) {
 		_ = cli.ShowCommandHelp(ctx, method)
 		fmt.Println()
 		return fmt.Errorf("invalid arguments: %q", ctx.Args())
@@ -36,10 +38,14 @@ func addpair(ctx *cli.Context) error {
 	}
 
 	configFile := ctx.Args().Get(0)
+	trustLimit := ctx.Args().Get(1)
 
-	log.Printf("admin addpair: %v", configFile)
+	log.Printf("admin addpair: %v %v", configFile, trustLimit)
 
 	params := []string{configFile}
+	if trustLimit != "" {
+		params = append(params, trustLimit)
+	}
 	result, err := adminCall(method, params)
 
 	log.Printf("result is '%v'", result)
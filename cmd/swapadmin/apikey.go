@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	apikeyCommand = &cli.Command{
+		Action:    apikey,
+		Name:      "apikey",
+		Usage:     "admin api key",
+		ArgsUsage: "<issue> <name> <rateLimit> <dailyQuota> [scopes]\n   <revoke> <keyID>",
+		Description: `
+issue or revoke api key of public api clients.
+rateLimit is max requests per second, dailyQuota is max requests per day (0 means no limit).
+scopes is comma separated extra permissions, 'adminread' permits reading admin apis.
+the issued api key is only shown once, only its keyID (the hash) is stored.
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func apikey(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "apikey"
+	if ctx.NArg() < 1 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	operation := ctx.Args().Get(0)
+	switch {
+	case operation == "issue" && (ctx.NArg() == 4 || ctx.NArg() == 5):
+	case operation == "revoke" && ctx.NArg() == 2:
+	default:
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("admin apikey: %v", params)
+
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	batchFileFlag = &cli.StringFlag{
+		Name:  "file",
+		Usage: "file of batch items, one item per line",
+	}
+	batchPairIDFlag = &cli.StringFlag{
+		Name:  "pairid",
+		Usage: "query swaps of pairID",
+	}
+	batchStatusFlag = &cli.StringFlag{
+		Name:  "status",
+		Usage: "query swaps with status (comma separated status numbers)",
+	}
+	batchMinValueFlag = &cli.StringFlag{
+		Name:  "minvalue",
+		Usage: "query swaps with value not less than minvalue",
+	}
+	batchMaxValueFlag = &cli.StringFlag{
+		Name:  "maxvalue",
+		Usage: "query swaps with value not greater than maxvalue",
+	}
+	batchMemoFlag = &cli.StringFlag{
+		Name:  "memo",
+		Usage: "memo of queried swaps (manual only)",
+	}
+	batchLimitFlag = &cli.IntFlag{
+		Name:  "limit",
+		Usage: "max number of queried swaps (default 100, max 1000)",
+	}
+	batchDryRunFlag = &cli.BoolFlag{
+		Name:  "dryrun",
+		Usage: "preview batch items without modification",
+	}
+
+	batchCommand = &cli.Command{
+		Action:    batch,
+		Name:      "batch",
+		Usage:     "admin batch operation",
+		ArgsUsage: "<bigvalue|reverify|reswap|manual|blacklist> <operation>",
+		Description: `
+run admin operation in batch on the server, items are specified by file or by query.
+
+items file contains one item per line, empty lines and lines starting with '#' are ignored.
+item is the params following the operation separated by white space, eg.
+    bigvalue, reverify, reswap: <txid> <pairID> <bind>
+    manual:                     <txid> <pairID> <bind> [memo (rest of line)]
+    blacklist:                  <address> <pairID>
+
+query is specified by flags, and is not supported by blacklist, eg.
+    swapadmin batch bigvalue passswapin --pairid btc --status 12 --maxvalue 100000000 --dryrun
+
+use --dryrun to preview the items and their current status before running.
+`,
+		Flags: append(append([]cli.Flag{}, commonAdminFlags...),
+			batchFileFlag,
+			batchPairIDFlag,
+			batchStatusFlag,
+			batchMinValueFlag,
+			batchMaxValueFlag,
+			batchMemoFlag,
+			batchLimitFlag,
+			batchDryRunFlag,
+		),
+	}
+)
+
+func batch(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "batch"
+	if ctx.NArg() != 2 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	spec, err := getBatchSpec(ctx)
+	if err != nil {
+		return err
+	}
+	specData, err := admin.EncodeBatchSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	err = prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	mode := admin.BatchModeRun
+	if ctx.Bool(batchDryRunFlag.Name) {
+		mode = admin.BatchModeDryRun
+	}
+	params := []string{ctx.Args().Get(0), ctx.Args().Get(1), mode, specData}
+
+	log.Printf("admin batch: %v %v %v, items %v, query %+v", params[0], params[1], mode, len(spec.Items), spec.Query)
+
+	result, err := adminCall(method, params)
+	if err != nil {
+		return err
+	}
+
+	resultStr, _ := result.(string)
+	var batchResult admin.BatchResult
+	if json.Unmarshal([]byte(resultStr), &batchResult) != nil {
+		log.Printf("result is '%v'", result)
+		return nil
+	}
+	for _, item := range batchResult.Items {
+		if item.Error != "" {
+			fmt.Printf("%v failed: %v\n", item.Params, item.Error)
+		} else {
+			fmt.Printf("%v success: %v\n", item.Params, item.Result)
+		}
+	}
+	log.Printf("batch %v %v dryrun=%v: total %v, success %v, failed %v",
+		batchResult.Method, batchResult.Operation, batchResult.DryRun,
+		batchResult.Total, batchResult.Success, batchResult.Failed)
+	return nil
+}
+
+func getBatchSpec(ctx *cli.Context) (*admin.BatchSpec, error) {
+	file := ctx.String(batchFileFlag.Name)
+	status := ctx.String(batchStatusFlag.Name)
+	switch {
+	case file != "" && status != "":
+		return nil, fmt.Errorf("--file and --status are exclusive")
+	case file != "":
+		items, err := readBatchItems(file, ctx.Args().Get(0) == "manual")
+		if err != nil {
+			return nil, err
+		}
+		return &admin.BatchSpec{Items: items}, nil
+	case status != "":
+		return &admin.BatchSpec{
+			Query: &admin.BatchQuery{
+				PairID:   ctx.String(batchPairIDFlag.Name),
+				Status:   status,
+				MinValue: ctx.String(batchMinValueFlag.Name),
+				MaxValue: ctx.String(batchMaxValueFlag.Name),
+				Memo:     ctx.String(batchMemoFlag.Name),
+				Limit:    ctx.Int(batchLimitFlag.Name),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("must specify --file or --status")
+	}
+}
+
+// memo of manual item is the remaining of the line
+func readBatchItems(file string, hasMemo bool) (items [][]string, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if hasMemo && len(fields) > 4 {
+			fields = append(fields[:3], strings.Join(fields[3:], " "))
+		}
+		items = append(items, fields)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no batch items in file %v", file)
+	}
+	return items, nil
+}
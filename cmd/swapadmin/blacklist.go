@@ -1,41 +1,100 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/admin"
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	allPairs = "all"
+
+	// same as max entries of one blacklist import admin call
+	blacklistImportChunkSize = 2000
+)
+
 var (
+	blacklistReasonFlag = &cli.StringFlag{
+		Name:  "reason",
+		Usage: "reason of blacklisting (default reason of imported entries)",
+	}
+	blacklistSourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "source of blacklisting, eg. name of sanction list (default source of imported entries)",
+	}
+	blacklistExpireAtFlag = &cli.StringFlag{
+		Name:  "expireat",
+		Usage: "expire time in unix seconds or RFC3339 format, never expire if not specified (default expire time of imported entries)",
+	}
+	blacklistPairIDFlag = &cli.StringFlag{
+		Name:  "pairid",
+		Usage: "default pairID of imported entries",
+		Value: allPairs,
+	}
+	blacklistFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "format of import file, csv or json (default by file extension)",
+	}
+
 	blacklistCommand = &cli.Command{
 		Action:    blacklist,
 		Name:      "blacklist",
 		Usage:     "admin blacklist",
-		ArgsUsage: "<add|remove|query> <address> <pairID>",
+		ArgsUsage: "<add|remove|query> <address> <pairID> | import <file>",
 		Description: `
-admin blacklist
+admin blacklist, pairID 'all' means all pairs.
+
+add entry with optional reason, source and expire time, eg.
+    swapadmin blacklist add 0x1111111111111111111111111111111111111111 all --reason "sanctioned" --source OFAC --expireat 2027-01-01T00:00:00Z
+
+import entries from csv or json file, addresses are matched case-insensitively, eg.
+    swapadmin blacklist import sanctions.csv --source OFAC
+
+csv file with header contains columns of address, pairid, reason, source, expireat (all but address are optional),
+otherwise the first column of each row is the address.
+json file is an array of addresses, or an array of objects with fields of address, pairid, reason, source, expireat.
+missing fields of imported entries are filled with the defaults specified by flags.
 `,
-		Flags: commonAdminFlags,
+		Flags: append(append([]cli.Flag{}, commonAdminFlags...),
+			blacklistReasonFlag,
+			blacklistSourceFlag,
+			blacklistExpireAtFlag,
+			blacklistPairIDFlag,
+			blacklistFormatFlag,
+		),
 	}
 )
 
 func blacklist(ctx *cli.Context) error {
 	utils.SetLogger(ctx)
 	method := "blacklist"
+	operation := ctx.Args().Get(0)
+	if operation == "import" {
+		if ctx.NArg() != 2 {
+			_ = cli.ShowCommandHelp(ctx, method)
+			fmt.Println()
+			return fmt.Errorf("invalid arguments: %q", ctx.Args())
+		}
+		return importBlacklist(ctx, ctx.Args().Get(1))
+	}
 	if ctx.NArg() != 3 {
 		_ = cli.ShowCommandHelp(ctx, method)
 		fmt.Println()
 		return fmt.Errorf("invalid arguments: %q", ctx.Args())
 	}
 
-	err := prepare(ctx)
-	if err != nil {
-		return err
-	}
-
-	operation := ctx.Args().Get(0)
 	address := ctx.Args().Get(1)
 	pairID := ctx.Args().Get(2)
 
@@ -45,11 +104,237 @@ func blacklist(ctx *cli.Context) error {
 		return fmt.Errorf("unknown operation '%v'", operation)
 	}
 
-	log.Printf("admin blacklist: %v %v %v", operation, address, pairID)
-
 	params := []string{operation, address, pairID}
+	if operation == "add" {
+		expireAt, err := parseExpireTime(ctx.String(blacklistExpireAtFlag.Name))
+		if err != nil {
+			return err
+		}
+		reason := ctx.String(blacklistReasonFlag.Name)
+		source := ctx.String(blacklistSourceFlag.Name)
+		if reason != "" || source != "" || expireAt != 0 {
+			params = append(params, reason, source, strconv.FormatInt(expireAt, 10))
+		}
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin blacklist: %v", params)
+
 	result, err := adminCall(method, params)
 
 	log.Printf("result is '%v'", result)
 	return err
 }
+
+func importBlacklist(ctx *cli.Context, file string) error {
+	defaults := &admin.BlacklistEntry{
+		PairID: ctx.String(blacklistPairIDFlag.Name),
+		Reason: ctx.String(blacklistReasonFlag.Name),
+		Source: ctx.String(blacklistSourceFlag.Name),
+	}
+	if defaults.PairID == "" {
+		defaults.PairID = allPairs
+	}
+	var err error
+	defaults.ExpireAt, err = parseExpireTime(ctx.String(blacklistExpireAtFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	entries, err := readBlacklistFile(file, ctx.String(blacklistFormatFlag.Name), defaults)
+	if err != nil {
+		return err
+	}
+	entries = dedupBlacklistEntries(entries)
+	if len(entries) == 0 {
+		return fmt.Errorf("no blacklist entries in file %v", file)
+	}
+
+	err = prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin blacklist import: %v entries from %v", len(entries), file)
+
+	for start := 0; start < len(entries); start += blacklistImportChunkSize {
+		end := start + blacklistImportChunkSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		data, err := admin.EncodeBlacklistEntries(entries[start:end])
+		if err != nil {
+			return err
+		}
+		result, err := adminCall("blacklist", []string{"import", data})
+		if err != nil {
+			return fmt.Errorf("import entries [%v, %v) failed: %w", start, end, err)
+		}
+		log.Printf("import entries [%v, %v): %v", start, end, result)
+	}
+	return nil
+}
+
+func readBlacklistFile(file, format string, defaults *admin.BlacklistEntry) ([]*admin.BlacklistEntry, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(file), ".")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var entries []*admin.BlacklistEntry
+	switch strings.ToLower(format) {
+	case "csv":
+		entries, err = readBlacklistCSV(f)
+	case "json":
+		entries, err = readBlacklistJSON(f)
+	default:
+		return nil, fmt.Errorf("unknown blacklist file format '%v', use --format to specify csv or json", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		if entry.Address == "" {
+			return nil, fmt.Errorf("empty address of entry %v", i)
+		}
+		if entry.PairID == "" {
+			entry.PairID = defaults.PairID
+		}
+		if entry.Reason == "" {
+			entry.Reason = defaults.Reason
+		}
+		if entry.Source == "" {
+			entry.Source = defaults.Source
+		}
+		if entry.ExpireAt == 0 {
+			entry.ExpireAt = defaults.ExpireAt
+		}
+	}
+	return entries, nil
+}
+
+func readBlacklistCSV(r io.Reader) (entries []*admin.BlacklistEntry, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{"address": 0}
+	hasHeader := false
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "address", "pairid", "reason", "source", "expireat":
+			columns[name] = i
+			if name == "address" {
+				hasHeader = true
+			}
+		}
+	}
+	if hasHeader {
+		records = records[1:]
+	} else {
+		columns = map[string]int{"address": 0}
+	}
+
+	getField := func(record []string, name string) string {
+		if i, exist := columns[name]; exist && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	for i, record := range records {
+		address := getField(record, "address")
+		if address == "" {
+			continue
+		}
+		expireAt, err := parseExpireTime(getField(record, "expireat"))
+		if err != nil {
+			return nil, fmt.Errorf("row %v: %w", i+1, err)
+		}
+		entries = append(entries, &admin.BlacklistEntry{
+			Address:  address,
+			PairID:   getField(record, "pairid"),
+			Reason:   getField(record, "reason"),
+			Source:   getField(record, "source"),
+			ExpireAt: expireAt,
+		})
+	}
+	return entries, nil
+}
+
+// json file is an array of addresses or an array of entry objects
+func readBlacklistJSON(r io.Reader) ([]*admin.BlacklistEntry, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	err = json.Unmarshal(data, &items)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*admin.BlacklistEntry, 0, len(items))
+	for i, item := range items {
+		var address string
+		if json.Unmarshal(item, &address) == nil {
+			entries = append(entries, &admin.BlacklistEntry{Address: strings.TrimSpace(address)})
+			continue
+		}
+		var entry admin.BlacklistEntry
+		err = json.Unmarshal(item, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("item %v: %w", i, err)
+		}
+		entry.Address = strings.TrimSpace(entry.Address)
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+// addresses of different chains are matched case-insensitively
+func dedupBlacklistEntries(entries []*admin.BlacklistEntry) []*admin.BlacklistEntry {
+	exist := make(map[string]struct{}, len(entries))
+	result := make([]*admin.BlacklistEntry, 0, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Address + ":" + entry.PairID)
+		if _, ok := exist[key]; ok {
+			continue
+		}
+		exist[key] = struct{}{}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// parseExpireTime parse unix seconds or RFC3339 time, empty means never expire
+func parseExpireTime(str string) (int64, error) {
+	if str == "" {
+		return 0, nil
+	}
+	if expireAt, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return expireAt, nil
+	}
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return 0, fmt.Errorf("wrong expire time '%v', use unix seconds or RFC3339 format", str)
+	}
+	return t.Unix(), nil
+}
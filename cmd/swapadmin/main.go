@@ -34,6 +34,12 @@ func initApp() {
 		manualCommand,
 		setnonceCommand,
 		addpairCommand,
+		updatepairCommand,
+		retirepairCommand,
+		trustsetCommand,
+		publishconfigCommand,
+		apikeyCommand,
+		batchCommand,
 		utils.LicenseCommand,
 		utils.VersionCommand,
 	}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	publishconfigCommand = &cli.Command{
+		Action:    publishconfig,
+		Name:      "publishconfig",
+		Usage:     "publish config bundle",
+		ArgsUsage: "[bundleHash]",
+		Description: `
+publish config bundle (chain configs and token pairs) of swap server
+with admin signature, oracles verify and compare it with local config.
+if bundleHash is not specified, print the current config bundle and its hash.
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func publishconfig(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "publishconfig"
+	if ctx.NArg() > 1 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := initSwapServer(ctx)
+	if err != nil {
+		return err
+	}
+
+	bundleHash := ctx.Args().Get(0)
+	if bundleHash == "" {
+		var bundle params.ConfigBundle
+		err = client.RPCPost(&bundle, swapServer, "swap.GetCurrentConfigBundle")
+		if err != nil {
+			return err
+		}
+		hash, errh := bundle.Hash()
+		if errh != nil {
+			return errh
+		}
+		bs, _ := json.MarshalIndent(bundle, "", "  ")
+		fmt.Println(string(bs))
+		log.Printf("config bundle hash is %v", hash.String())
+		return nil
+	}
+
+	err = loadKeyStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin publishconfig: %v", bundleHash)
+
+	result, err := adminCall(method, []string{bundleHash})
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	trustsetCommand = &cli.Command{
+		Action:    trustset,
+		Name:      "trustset",
+		Usage:     "admin ripple trustline",
+		ArgsUsage: "<pairID> <trustLimit>",
+		Description: `
+create or update trustline of dcrm address for ripple non native pair,
+trustLimit is in unit of the issued currency (eg. 1000000).
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func trustset(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "trustset"
+	if ctx.NArg() != 2 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	pairID := ctx.Args().Get(0)
+	trustLimit := ctx.Args().Get(1)
+
+	log.Printf("admin trustset: %v %v", pairID, trustLimit)
+
+	params := []string{pairID, trustLimit}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
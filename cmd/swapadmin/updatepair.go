@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	updatepairCommand = &cli.Command{
+		Action:    updatepair,
+		Name:      "updatepair",
+		Usage:     "update token pair",
+		ArgsUsage: "<configFile>",
+		Description: `
+update existing token pair dynamically through config file,
+the 'Version' in config file must be greater than current version.
+safe fields apply immediately, unsafe fields (eg. dcrm address,
+contract address) apply after 'ActivateHeight' or 'ActivateTime'.
+`,
+		Flags: commonAdminFlags,
+	}
+
+	retirepairCommand = &cli.Command{
+		Action:    retirepair,
+		Name:      "retirepair",
+		Usage:     "retire token pair",
+		ArgsUsage: "<pairID>",
+		Description: `
+retire token pair by disabling swap in both directions,
+the pair config is kept to process the swaps on the way.
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func updatepair(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "updatepair"
+	if ctx.NArg() != 1 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	configFile := ctx.Args().Get(0)
+
+	log.Printf("admin updatepair: %v", configFile)
+
+	result, err := adminCall(method, []string{configFile})
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func retirepair(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "retirepair"
+	if ctx.NArg() != 1 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	pairID := ctx.Args().Get(0)
+
+	log.Printf("admin retirepair: %v", pairID)
+
+	result, err := adminCall(method, []string{pairID})
+
+	log.Printf("result is '%v'", result)
+	return err
+}
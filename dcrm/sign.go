@@ -30,6 +30,10 @@ var (
 	errGetSignResultFailed  = errors.New("get sign result failed")
 	errRValueIsUsed         = errors.New("r value is already used")
 	errWrongSignatureLength = errors.New("wrong signature length")
+
+	// SignRequestedHook is called when sign request is accepted by dcrm node,
+	// it's used to persist keyID to resume signing after restart.
+	SignRequestedHook func(keyID string, msgHash, msgContext []string)
 )
 
 func pingDcrmNode(nodeInfo *NodeInfo) (err error) {
@@ -127,6 +131,9 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signPubkey string, msg
 	if err != nil {
 		return "", nil, err
 	}
+	if SignRequestedHook != nil {
+		SignRequestedHook(keyID, msgHash, msgContext)
+	}
 
 	rsvs, err = getSignResult(keyID, rpcAddr)
 	if err != nil {
@@ -0,0 +1,207 @@
+package swapapi
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/worker"
+)
+
+const (
+	overReplaceLimitReason = "OverReplaceLimit"
+
+	// oracle report heartbeat every 2 minutes
+	oracleHeartbeatTimeout = int64(600) // seconds
+)
+
+var (
+	// swap results awaiting admin action
+	pendingSwapResultStatuses = []mongodb.SwapStatus{
+		mongodb.TxWithBigValue,
+		mongodb.TxWithWrongMemo,
+		mongodb.MatchTxFailed,
+	}
+
+	// swaps awaiting admin action, which may have no swap result
+	pendingSwapStatuses = []mongodb.SwapStatus{
+		mongodb.SwapInBlacklist,
+	}
+)
+
+type balanceGetter interface {
+	GetBalance(account string) (*big.Int, error)
+}
+
+type erc20BalanceGetter interface {
+	GetErc20Balance(contract, address string) (*big.Int, error)
+}
+
+func processAdminListLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		limit = 100 // default
+	case limit > 1000:
+		limit = 1000
+	}
+	return limit
+}
+
+// GetPendingSwaps get swaps awaiting admin action, oldest first
+func GetPendingSwaps(limit int) ([]*PendingSwap, error) {
+	limit = processAdminListLimit(limit)
+	result := make([]*PendingSwap, 0, limit)
+	for _, isSwapin := range []bool{true, false} {
+		swaps, err := getPendingSwaps(isSwapin, limit)
+		if err != nil {
+			return nil, newRPCInternalError(err)
+		}
+		result = append(result, swaps...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].InitTime < result[j].InitTime
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func getPendingSwaps(isSwapin bool, limit int) ([]*PendingSwap, error) {
+	direction := "swapout"
+	if isSwapin {
+		direction = "swapin"
+	}
+	now := common.NowMilli()
+	newPendingSwap := func(info *SwapInfo, reason string) *PendingSwap {
+		return &PendingSwap{
+			SwapInfo:  *info,
+			Direction: direction,
+			Reason:    reason,
+			Age:       (now - info.InitTime) / 1000,
+		}
+	}
+
+	maxReplaceCount := worker.GetMaxReplaceCount(isSwapin)
+	results, err := mongodb.FindSwapResultsWithStatusesOrOverReplaced(isSwapin, pendingSwapResultStatuses, maxReplaceCount, limit)
+	if err != nil {
+		return nil, err
+	}
+	pendings := make([]*PendingSwap, 0, len(results))
+	for _, res := range results {
+		reason := res.Status.String()
+		if res.Status == mongodb.MatchTxNotStable {
+			reason = overReplaceLimitReason
+		}
+		pendings = append(pendings, newPendingSwap(ConvertMgoSwapResultToSwapInfo(res), reason))
+	}
+
+	swaps, err := mongodb.FindSwapsWithStatuses(isSwapin, "", pendingSwapStatuses, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, swap := range swaps {
+		info := ConvertMgoSwapToSwapInfo(swap)
+		// use swap result for more info (eg. value) if exist
+		if res, errf := mongodb.FindSwapResult(isSwapin, swap.TxID, swap.PairID, swap.Bind); errf == nil {
+			info = ConvertMgoSwapResultToSwapInfo(res)
+			info.Status = swap.Status
+			info.StatusMsg = swap.Status.String()
+		}
+		pendings = append(pendings, newPendingSwap(info, swap.Status.String()))
+	}
+	return pendings, nil
+}
+
+// GetDcrmAccounts get balances and nonces of dcrm addresses of all token pairs
+func GetDcrmAccounts() ([]*PairDcrmAccounts, error) {
+	swapinNonces, swapoutNonces := mongodb.LoadAllSwapNonces()
+	pairIDs := tokens.GetAllPairIDs()
+	result := make([]*PairDcrmAccounts, 0, len(pairIDs))
+	for _, pairID := range pairIDs {
+		pairCfg := tokens.GetTokenPairConfig(pairID)
+		if pairCfg == nil {
+			continue
+		}
+		result = append(result, &PairDcrmAccounts{
+			PairID:      pairID,
+			SrcAccounts: getDcrmAccounts(tokens.SrcBridge, pairCfg.SrcToken, swapoutNonces),
+			DstAccounts: getDcrmAccounts(tokens.DstBridge, pairCfg.DestToken, swapinNonces),
+		})
+	}
+	return result, nil
+}
+
+func getDcrmAccounts(bridge tokens.CrossChainBridge, tokenCfg *tokens.TokenConfig, swapNonces map[string]uint64) []*DcrmAccount {
+	addresses := tokenCfg.GetDcrmAddresses()
+	accounts := make([]*DcrmAccount, 0, len(addresses))
+	for _, address := range addresses {
+		account := &DcrmAccount{
+			Address:   address,
+			SwapNonce: swapNonces[strings.ToLower(address)],
+		}
+		var errs []string
+		if getter, ok := bridge.(balanceGetter); ok {
+			balance, err := getter.GetBalance(address)
+			if err != nil {
+				errs = append(errs, err.Error())
+			} else if balance != nil {
+				account.Balance = balance.String()
+			}
+		}
+		if getter, ok := bridge.(erc20BalanceGetter); ok && tokenCfg.ContractAddress != "" {
+			balance, err := getter.GetErc20Balance(tokenCfg.ContractAddress, address)
+			if err != nil {
+				errs = append(errs, err.Error())
+			} else if balance != nil {
+				account.TokenBalance = balance.String()
+			}
+		}
+		if nonceGetter, ok := bridge.(tokens.NonceSetter); ok {
+			nonce, err := nonceGetter.GetPoolNonce(address, "pending")
+			if err != nil {
+				errs = append(errs, err.Error())
+			} else {
+				account.PoolNonce = nonce
+			}
+		}
+		account.Error = strings.Join(errs, "; ")
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+// GetBlacklist get blacklist
+func GetBlacklist(offset, limit int) ([]*BlackAccount, error) {
+	result, err := mongodb.FindBlacklist(offset, processAdminListLimit(limit))
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return result, nil
+}
+
+// GetOraclesStatus get heartbeat status of all oracles
+func GetOraclesStatus() []*OracleStatus {
+	now := time.Now().Unix()
+	selfEnode := dcrm.GetSelfEnode()
+	enodes := dcrm.GetAllEnodes()
+	result := make([]*OracleStatus, 0, len(enodes))
+	for _, enode := range enodes {
+		if strings.EqualFold(enode, selfEnode) {
+			continue
+		}
+		status := &OracleStatus{Enode: getEnodeID(enode)}
+		if value, ok := oraclesHeartbeats.Load(strings.ToLower(enode)); ok {
+			status.LastHeartbeat = value.(int64)
+			status.Age = now - status.LastHeartbeat
+			status.IsAlive = status.Age <= oracleHeartbeatTimeout
+		}
+		result = append(result, status)
+	}
+	return result
+}
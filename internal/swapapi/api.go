@@ -2,6 +2,10 @@ package swapapi
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +16,8 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/btcsuite/btcd/txscript"
 	rpcjson "github.com/gorilla/rpc/v2/json2"
 )
@@ -20,6 +26,10 @@ var (
 	errNotBtcBridge      = newRPCError(-32096, "bridge is not btc")
 	errTokenPairNotExist = newRPCError(-32095, "token pair not exist")
 	errSwapCannotRetry   = newRPCError(-32094, "swap can not retry")
+	errNotRippleBridge   = newRPCError(-32093, "bridge is not ripple")
+	errInvalidBindAddr   = newRPCError(-32092, "invalid bind address")
+	errInvalidDirection  = newRPCError(-32091, "invalid direction, must be swapin or swapout")
+	errInvalidCursor     = newRPCError(-32090, "invalid cursor")
 
 	oraclesHeartbeats sync.Map // string -> int64 // key is enode
 )
@@ -83,20 +93,27 @@ func UpdateOracleHeartbeat(oracle string, timestamp int64) error {
 func GetOraclesHeartbeat() map[string]string {
 	result := make(map[string]string, 4)
 	oraclesHeartbeats.Range(func(k, v interface{}) bool {
-		enode := k.(string)
-		startIndex := strings.Index(enode, "enode://")
-		endIndex := strings.Index(enode, "@")
-		if startIndex != -1 && endIndex != -1 {
-			enodeID := enode[startIndex+8 : endIndex]
+		enodeID := getEnodeID(k.(string))
+		if enodeID != "" {
 			timestamp := v.(int64)
 			timeStr := time.Unix(timestamp, 0).Format(time.RFC3339)
-			result[strings.ToLower(enodeID)] = timeStr
+			result[enodeID] = timeStr
 		}
 		return true
 	})
 	return result
 }
 
+// get lower case node id of enode url, return empty if wrong format
+func getEnodeID(enode string) string {
+	startIndex := strings.Index(enode, "enode://")
+	endIndex := strings.Index(enode, "@")
+	if startIndex == -1 || endIndex == -1 {
+		return ""
+	}
+	return strings.ToLower(enode[startIndex+8 : endIndex])
+}
+
 // GetStatusInfo api
 func GetStatusInfo(status string) (map[string]map[string]interface{}, error) {
 	return mongodb.GetStatusInfo(status)
@@ -129,12 +146,19 @@ func GetTokenPairsInfo(pairIDs string) (map[string]*tokens.TokenPairConfig, erro
 // GetNonceInfo api
 func GetNonceInfo() (*SwapNonceInfo, error) {
 	swapinNonces, swapoutNonces := mongodb.LoadAllSwapNonces()
+	nonceLedgers, _ := mongodb.FindUnconfirmedSwapNonceLedgers()
 	return &SwapNonceInfo{
 		SwapinNonces:  swapinNonces,
 		SwapoutNonces: swapoutNonces,
+		NonceLedgers:  nonceLedgers,
 	}, nil
 }
 
+// GetGatewayHealth api
+func GetGatewayHealth() map[string][]*tools.EndpointHealth {
+	return tools.GetGatewayHealth()
+}
+
 // GetRawSwapin api
 func GetRawSwapin(txid, pairID, bindAddr *string) (*Swap, error) {
 	return mongodb.FindSwapin(*txid, *pairID, *bindAddr)
@@ -221,6 +245,147 @@ func GetSwapoutHistory(address, pairID string, offset, limit int, status string)
 	return ConvertMgoSwapResultsToSwapInfos(result), nil
 }
 
+// SearchSwaps search swaps with filters and cursor pagination
+func SearchSwaps(args *SearchSwapsArgs) (*SearchSwapsResult, error) {
+	log.Debug("[api] receive SearchSwaps", "args", args)
+	var searchSwapin, searchSwapout bool
+	switch strings.ToLower(args.Direction) {
+	case "swapin":
+		searchSwapin = true
+	case "swapout":
+		searchSwapout = true
+	case "":
+		searchSwapin, searchSwapout = true, true
+	default:
+		return nil, errInvalidDirection
+	}
+	filter := &mongodb.SwapSearchFilter{
+		PairIDs:   args.PairIDs,
+		From:      args.From,
+		Bind:      args.Bind,
+		TxID:      args.TxID,
+		SwapTx:    args.SwapTx,
+		Status:    args.Status,
+		StartTime: args.StartTime,
+		EndTime:   args.EndTime,
+		MinValue:  args.MinValue,
+		MaxValue:  args.MaxValue,
+		Ascending: args.Ascending,
+		Limit:     processSearchLimit(args.Limit),
+	}
+	if args.Cursor != "" {
+		var err error
+		filter.CursorTime, filter.CursorKey, err = parseSearchCursor(args.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var swapins, swapouts []*mongodb.MgoSwapResult
+	var err error
+	if searchSwapin {
+		swapins, err = mongodb.SearchSwapResults(true, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if searchSwapout {
+		swapouts, err = mongodb.SearchSwapResults(false, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	results := mergeSearchResults(swapins, swapouts, filter.Ascending, filter.Limit)
+	searchResult := &SearchSwapsResult{
+		Swaps: ConvertMgoSwapResultsToSwapInfos(results),
+	}
+	if len(results) == filter.Limit {
+		last := results[len(results)-1]
+		searchResult.NextCursor = fmt.Sprintf("%d:%s", last.InitTime, last.Key)
+	}
+	return searchResult, nil
+}
+
+func processSearchLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		limit = 20 // default
+	case limit > 100:
+		limit = 100
+	}
+	return limit
+}
+
+// cursor format is 'inittime:key'
+func parseSearchCursor(cursor string) (initTime int64, key string, err error) {
+	parts := strings.SplitN(cursor, ":", 2)
+	if len(parts) != 2 {
+		return 0, "", errInvalidCursor
+	}
+	initTime, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || initTime <= 0 {
+		return 0, "", errInvalidCursor
+	}
+	return initTime, parts[1], nil
+}
+
+// merge two sorted lists by (inittime, key) and keep at most limit items
+func mergeSearchResults(a, b []*mongodb.MgoSwapResult, ascending bool, limit int) []*mongodb.MgoSwapResult {
+	less := func(x, y *mongodb.MgoSwapResult) bool {
+		if x.InitTime != y.InitTime {
+			return (x.InitTime < y.InitTime) == ascending
+		}
+		return (x.Key < y.Key) == ascending
+	}
+	result := make([]*mongodb.MgoSwapResult, 0, len(a)+len(b))
+	i, j := 0, 0
+	for len(result) < limit && (i < len(a) || j < len(b)) {
+		if j >= len(b) || (i < len(a) && less(a[i], b[j])) {
+			result = append(result, a[i])
+			i++
+		} else {
+			result = append(result, b[j])
+			j++
+		}
+	}
+	return result
+}
+
+// GetSwapBySwapTx get swaps by swap tx (including replaced swap txs)
+func GetSwapBySwapTx(swapTx string) (*SwapBySwapTxResult, error) {
+	log.Debug("[api] receive GetSwapBySwapTx", "swapTx", swapTx)
+	if swapTx == "" {
+		return nil, mongodb.ErrSwapNotFound
+	}
+	filter := &mongodb.SwapSearchFilter{
+		SwapTx: swapTx,
+		Limit:  100,
+	}
+	swapins, err := mongodb.SearchSwapResults(true, filter)
+	if err != nil {
+		return nil, err
+	}
+	swapouts, err := mongodb.SearchSwapResults(false, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := &SwapBySwapTxResult{
+		SwapTx: swapTx,
+		Swaps:  append(ConvertMgoSwapResultsToSwapInfos(swapins), ConvertMgoSwapResultsToSwapInfos(swapouts)...),
+	}
+	if len(result.Swaps) != 0 {
+		return result, nil
+	}
+	// aggregate tx is sent on source chain without swap records
+	if checker, ok := tokens.SrcBridge.(tokens.AggregateTxChecker); ok {
+		isAggregate, errc := checker.IsAggregateTx(swapTx)
+		if errc == nil && isAggregate {
+			result.IsAggregateTx = true
+			return result, nil
+		}
+	}
+	return nil, mongodb.ErrSwapNotFound
+}
+
 // Swapin api
 func Swapin(txid, pairID *string) (*PostResult, error) {
 	log.Debug("[api] receive Swapin", "txid", *txid, "pairID", *pairID)
@@ -382,6 +547,76 @@ func calcP2shAddress(bindAddress string, addToDatabase bool) (*tokens.P2shAddres
 	}, nil
 }
 
+// RegisterDestinationTag api
+func RegisterDestinationTag(bindAddress string) (*tokens.DestinationTagInfo, error) {
+	if _, ok := tokens.SrcBridge.(*ripple.Bridge); !ok {
+		return nil, errNotRippleBridge
+	}
+	if !tokens.DstBridge.IsValidAddress(bindAddress) {
+		return nil, errInvalidBindAddr
+	}
+	result, _ := mongodb.FindDestinationTagByBind(bindAddress)
+	if result != nil {
+		return &tokens.DestinationTagInfo{
+			BindAddress:    result.BindAddress,
+			DestinationTag: result.Key,
+		}, nil
+	}
+	// bind address is unique indexed, return the existing tag if registered
+	// concurrently, otherwise retry on the very unlikely duplicate random tag
+	for i := 0; i < 10; i++ {
+		tag, err := ripple.NewDestinationTag()
+		if err != nil {
+			return nil, newRPCInternalError(err)
+		}
+		err = mongodb.AddDestinationTag(&mongodb.MgoDestinationTag{
+			Key:         tag,
+			BindAddress: bindAddress,
+		})
+		if err == nil {
+			log.Info("[api] register destination tag", "bind", bindAddress, "tag", tag)
+			return &tokens.DestinationTagInfo{
+				BindAddress:    bindAddress,
+				DestinationTag: tag,
+			}, nil
+		}
+		if !errors.Is(err, mongodb.ErrItemIsDup) {
+			return nil, err
+		}
+		if result, _ = mongodb.FindDestinationTagByBind(bindAddress); result != nil {
+			return &tokens.DestinationTagInfo{
+				BindAddress:    result.BindAddress,
+				DestinationTag: result.Key,
+			}, nil
+		}
+	}
+	return nil, newRPCError(-32000, "rpcError: allocate destination tag failed")
+}
+
+// GetDestinationTagInfo api
+func GetDestinationTagInfo(tag uint32) (*tokens.DestinationTagInfo, error) {
+	result, err := mongodb.FindDestinationTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tokens.DestinationTagInfo{
+		BindAddress:    result.BindAddress,
+		DestinationTag: result.Key,
+	}, nil
+}
+
+// GetBindDestinationTag api
+func GetBindDestinationTag(bindAddress string) (*tokens.DestinationTagInfo, error) {
+	result, err := mongodb.FindDestinationTagByBind(bindAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &tokens.DestinationTagInfo{
+		BindAddress:    result.BindAddress,
+		DestinationTag: result.Key,
+	}, nil
+}
+
 // P2shSwapin api
 func P2shSwapin(txid, bindAddr *string) (*PostResult, error) {
 	log.Debug("[api] receive P2shSwapin", "txid", *txid, "bindAddress", *bindAddr)
@@ -447,3 +682,66 @@ func GetRegisteredAddress(address string) (*RegisteredAddress, error) {
 	address = strings.ToLower(address)
 	return mongodb.FindRegisteredAddress(address)
 }
+
+var (
+	publishedConfigBundle *params.SignedConfigBundle
+	configBundleLock      sync.RWMutex
+)
+
+// GetCurrentConfigBundle api
+func GetCurrentConfigBundle() (*params.ConfigBundle, error) {
+	return params.BuildConfigBundle(), nil
+}
+
+// GetConfigBundle api
+func GetConfigBundle() (*params.SignedConfigBundle, error) {
+	configBundleLock.RLock()
+	signed := publishedConfigBundle
+	configBundleLock.RUnlock()
+	if signed != nil {
+		return signed, nil
+	}
+
+	// load the bundle published before restart
+	mc, err := mongodb.FindConfigBundle()
+	if err != nil {
+		return nil, err
+	}
+	var bundle params.ConfigBundle
+	err = json.Unmarshal([]byte(mc.Bundle), &bundle)
+	if err != nil {
+		return nil, err
+	}
+	signed = &params.SignedConfigBundle{
+		Bundle:    &bundle,
+		Signature: mc.Signature,
+	}
+	configBundleLock.Lock()
+	defer configBundleLock.Unlock()
+	if publishedConfigBundle == nil {
+		publishedConfigBundle = signed
+	}
+	return publishedConfigBundle, nil
+}
+
+// PublishConfigBundle publish config bundle with admin signature
+func PublishConfigBundle(bundle *params.ConfigBundle, signature string) error {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	configBundleLock.Lock()
+	defer configBundleLock.Unlock()
+	err = mongodb.UpdateConfigBundle(&mongodb.MgoConfigBundle{
+		Bundle:    string(data),
+		Signature: signature,
+	})
+	if err != nil {
+		return err
+	}
+	publishedConfigBundle = &params.SignedConfigBundle{
+		Bundle:    bundle,
+		Signature: signature,
+	}
+	return nil
+}
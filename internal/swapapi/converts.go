@@ -65,6 +65,8 @@ func ConvertMgoSwapResultToSwapInfo(mr *mongodb.MgoSwapResult) *SwapInfo {
 		Memo:          mr.Memo,
 		ReplaceCount:  len(mr.OldSwapTxs),
 		Confirmations: confirmations,
+		TokenPrice:    mr.TokenPrice,
+		SwapFrom:      mr.SwapFrom,
 	}
 }
 
@@ -20,6 +20,12 @@ type LatestScanInfo = mongodb.MgoLatestScanInfo
 // RegisteredAddress type alias
 type RegisteredAddress = mongodb.MgoRegisteredAddress
 
+// SwapNonceLedger type alias
+type SwapNonceLedger = mongodb.MgoSwapNonceLedger
+
+// BlackAccount type alias
+type BlackAccount = mongodb.MgoBlackAccount
+
 // ServerInfo server info
 type ServerInfo struct {
 	Identifier          string
@@ -58,10 +64,77 @@ type SwapInfo struct {
 	Memo          string     `json:"memo"`
 	ReplaceCount  int        `json:"replaceCount"`
 	Confirmations uint64     `json:"confirmations"`
+	TokenPrice    float64    `json:"tokenPrice,omitempty"`
+	SwapFrom      string     `json:"swapfrom,omitempty"`
 }
 
 // SwapNonceInfo swap nonce info
 type SwapNonceInfo struct {
-	SwapinNonces  map[string]uint64 `json:"swapinNonces"`
-	SwapoutNonces map[string]uint64 `json:"swapoutNonces"`
+	SwapinNonces  map[string]uint64  `json:"swapinNonces"`
+	SwapoutNonces map[string]uint64  `json:"swapoutNonces"`
+	NonceLedgers  []*SwapNonceLedger `json:"nonceLedgers,omitempty"`
+}
+
+// SearchSwapsArgs search swaps args
+type SearchSwapsArgs struct {
+	Direction string   `json:"direction"` // swapin, swapout, or empty for both
+	PairIDs   []string `json:"pairids"`
+	From      string   `json:"from"`
+	Bind      string   `json:"bind"`
+	TxID      string   `json:"txid"`
+	SwapTx    string   `json:"swaptx"`    // match current and replaced swap txs
+	Status    string   `json:"status"`    // comma separated status numbers
+	StartTime int64    `json:"starttime"` // inittime in milliseconds, inclusive
+	EndTime   int64    `json:"endtime"`   // inittime in milliseconds, exclusive
+	MinValue  string   `json:"minvalue"`
+	MaxValue  string   `json:"maxvalue"`
+	Cursor    string   `json:"cursor"` // 'nextCursor' of previous page
+	Limit     int      `json:"limit"`
+	Ascending bool     `json:"ascending"`
+}
+
+// SearchSwapsResult search swaps result
+type SearchSwapsResult struct {
+	Swaps      []*SwapInfo `json:"swaps"`
+	NextCursor string      `json:"nextCursor"` // empty if no more pages
+}
+
+// SwapBySwapTxResult swaps of the swap tx
+type SwapBySwapTxResult struct {
+	SwapTx        string      `json:"swaptx"`
+	IsAggregateTx bool        `json:"isAggregateTx"`
+	Swaps         []*SwapInfo `json:"swaps"`
+}
+
+// PendingSwap swap awaiting admin action
+type PendingSwap struct {
+	SwapInfo
+	Direction string `json:"direction"` // swapin or swapout
+	Reason    string `json:"reason"`    // status name, or 'OverReplaceLimit'
+	Age       int64  `json:"age"`       // seconds since inittime
+}
+
+// DcrmAccount balance and nonce state of dcrm address
+type DcrmAccount struct {
+	Address      string `json:"address"`
+	Balance      string `json:"balance"`                // native balance
+	TokenBalance string `json:"tokenBalance,omitempty"` // balance of token contract
+	SwapNonce    uint64 `json:"swapNonce"`              // latest swap nonce in database
+	PoolNonce    uint64 `json:"poolNonce,omitempty"`    // pending nonce on chain (eth like)
+	Error        string `json:"error,omitempty"`
+}
+
+// PairDcrmAccounts dcrm accounts of token pair
+type PairDcrmAccounts struct {
+	PairID      string         `json:"pairid"`
+	SrcAccounts []*DcrmAccount `json:"srcAccounts"` // on source chain, send swapout
+	DstAccounts []*DcrmAccount `json:"dstAccounts"` // on dest chain, send swapin
+}
+
+// OracleStatus oracle heartbeat status
+type OracleStatus struct {
+	Enode         string `json:"enode"`         // node id of enode
+	LastHeartbeat int64  `json:"lastHeartbeat"` // unix seconds, 0 if never reported
+	Age           int64  `json:"age"`           // seconds since last heartbeat
+	IsAlive       bool   `json:"isAlive"`
 }
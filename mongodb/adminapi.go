@@ -11,35 +11,66 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
 	minTimeIntervalToReswap = int64(300) // seconds
 )
 
+var errSwapSucceeded = errors.New("swap succeed")
+
 // --------------- blacklist --------------------------------
 
 func getBlacklistKey(address, pairID string) string {
 	return strings.ToLower(address + ":" + pairID)
 }
 
+func initBlackAccount(mb *MgoBlackAccount) {
+	mb.Address = strings.ToLower(mb.Address)
+	mb.PairID = strings.ToLower(mb.PairID)
+	mb.Key = getBlacklistKey(mb.Address, mb.PairID)
+	mb.Timestamp = time.Now().Unix()
+}
+
 // AddToBlacklist add to blacklist
-func AddToBlacklist(address, pairID string) error {
-	mb := &MgoBlackAccount{
-		Key:       getBlacklistKey(address, pairID),
-		Address:   strings.ToLower(address),
-		PairID:    strings.ToLower(pairID),
-		Timestamp: time.Now().Unix(),
-	}
+func AddToBlacklist(mb *MgoBlackAccount) error {
+	initBlackAccount(mb)
 	_, err := collBlacklist.InsertOne(clientCtx, mb)
 	if err == nil {
-		log.Info("mongodb add to black list success", "address", address, "pairID", pairID)
+		log.Info("mongodb add to black list success", "address", mb.Address, "pairID", mb.PairID, "reason", mb.Reason, "source", mb.Source, "expireAt", mb.ExpireAt)
 	} else {
-		log.Info("mongodb add to black list failed", "address", address, "pairID", pairID, "err", err)
+		log.Info("mongodb add to black list failed", "address", mb.Address, "pairID", mb.PairID, "err", err)
 	}
 	return mgoError(err)
 }
 
+// ImportBlacklist add or replace blacklist entries in bulk
+func ImportBlacklist(entries []*MgoBlackAccount) (inserted, replaced int64, err error) {
+	if len(entries) == 0 {
+		return 0, 0, nil
+	}
+	models := make([]mongo.WriteModel, len(entries))
+	for i, mb := range entries {
+		initBlackAccount(mb)
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": mb.Key}).
+			SetReplacement(mb).
+			SetUpsert(true)
+	}
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := collBlacklist.BulkWrite(clientCtx, models, opts)
+	if res != nil {
+		inserted, replaced = res.UpsertedCount, res.ModifiedCount
+	}
+	if err == nil {
+		log.Info("mongodb import black list success", "count", len(entries), "inserted", inserted, "replaced", replaced)
+	} else {
+		log.Info("mongodb import black list failed", "count", len(entries), "inserted", inserted, "replaced", replaced, "err", err)
+	}
+	return inserted, replaced, mgoError(err)
+}
+
 // RemoveFromBlacklist remove from blacklist
 func RemoveFromBlacklist(address, pairID string) error {
 	_, err := collBlacklist.DeleteOne(clientCtx, bson.M{"_id": getBlacklistKey(address, pairID)})
@@ -53,17 +84,56 @@ func RemoveFromBlacklist(address, pairID string) error {
 
 // QueryBlacklist query if is blacked
 func QueryBlacklist(address, pairID string) (isBlacked bool, err error) {
-	var result MgoBlackAccount
-	err = collBlacklist.FindOne(clientCtx, bson.M{"_id": getBlacklistKey(address, pairID)}).Decode(&result)
+	_, err = FindBlacklistEntry(address, pairID)
 	if err == nil {
 		return true, nil
 	}
-	if errors.Is(err, mongo.ErrNoDocuments) {
+	if errors.Is(err, ErrItemNotFound) {
 		return false, nil
 	}
 	return false, err
 }
 
+// FindBlacklistEntry find not expired blacklist entry of the address,
+// which is blacked on the pair or on all pairs
+func FindBlacklistEntry(address, pairID string) (*MgoBlackAccount, error) {
+	keys := []string{getBlacklistKey(address, pairID)}
+	if !strings.EqualFold(pairID, allPairs) {
+		keys = append(keys, getBlacklistKey(address, allPairs))
+	}
+	cur, err := collBlacklist.Find(clientCtx, bson.M{"_id": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	var result []*MgoBlackAccount
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	now := time.Now().Unix()
+	for _, mb := range result {
+		if !mb.IsExpired(now) {
+			return mb, nil
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
+// FindBlacklist find blacklist
+func FindBlacklist(offset, limit int) ([]*MgoBlackAccount, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cur, err := collBlacklist.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoBlackAccount, 0, limit)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
 // PassSwapinBigValue pass swapin big value
 func PassSwapinBigValue(txid, pairID, bind string) error {
 	return passBigValue(txid, pairID, bind, true)
@@ -75,6 +145,19 @@ func PassSwapoutBigValue(txid, pairID, bind string) error {
 }
 
 func passBigValue(txid, pairID, bind string, isSwapin bool) error {
+	err := CheckPassBigValue(txid, pairID, bind, isSwapin)
+	if err != nil {
+		return err
+	}
+	err = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, MatchTxEmpty, time.Now().Unix(), "")
+	if err != nil {
+		return err
+	}
+	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "")
+}
+
+// CheckPassBigValue check big value swap can be passed (without modification)
+func CheckPassBigValue(txid, pairID, bind string, isSwapin bool) error {
 	swap, err := FindSwap(isSwapin, txid, pairID, bind)
 	if err != nil {
 		return err
@@ -89,11 +172,7 @@ func passBigValue(txid, pairID, bind string, isSwapin bool) error {
 	if res.SwapTx != "" || res.SwapHeight != 0 || len(res.OldSwapTxs) > 0 {
 		return fmt.Errorf("already swapped with swaptx %v", res.SwapTx)
 	}
-	err = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, MatchTxEmpty, time.Now().Unix(), "")
-	if err != nil {
-		return err
-	}
-	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "")
+	return nil
 }
 
 // ReverifySwapin reverify swapin
@@ -107,6 +186,15 @@ func ReverifySwapout(txid, pairID, bind string) error {
 }
 
 func reverifySwap(txid, pairID, bind string, isSwapin bool) error {
+	err := CheckReverifySwap(txid, pairID, bind, isSwapin)
+	if err != nil {
+		return err
+	}
+	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), "")
+}
+
+// CheckReverifySwap check swap can be reverified (without modification)
+func CheckReverifySwap(txid, pairID, bind string, isSwapin bool) error {
 	swap, err := FindSwap(isSwapin, txid, pairID, bind)
 	if err != nil {
 		return err
@@ -114,7 +202,7 @@ func reverifySwap(txid, pairID, bind string, isSwapin bool) error {
 	if !swap.Status.CanReverify() {
 		return fmt.Errorf("swap status is %v, no need to reverify", swap.Status.String())
 	}
-	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), "")
+	return nil
 }
 
 // Reswapin reswapin
@@ -128,19 +216,11 @@ func Reswapout(txid, pairID, bind string) error {
 }
 
 func reswap(txid, pairID, bind string, isSwapin bool) error {
-	swap, err := FindSwap(isSwapin, txid, pairID, bind)
-	if err != nil {
-		return err
-	}
-	if !swap.Status.CanReswap() {
-		return fmt.Errorf("swap status is %v, can not reswap", swap.Status.String())
-	}
-	swapResult, err := FindSwapResult(isSwapin, txid, pairID, bind)
-	if err != nil {
-		return err
-	}
-	err = checkCanReswap(swapResult, isSwapin)
+	swapResult, err := checkReswap(txid, pairID, bind, isSwapin)
 	if err != nil {
+		if errors.Is(err, errSwapSucceeded) {
+			_ = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, MatchTxNotStable, time.Now().Unix(), "")
+		}
 		return err
 	}
 
@@ -153,11 +233,35 @@ func reswap(txid, pairID, bind string, isSwapin bool) error {
 	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "")
 }
 
+// CheckReswap check swap can be reswapped (without modification)
+func CheckReswap(txid, pairID, bind string, isSwapin bool) error {
+	_, err := checkReswap(txid, pairID, bind, isSwapin)
+	return err
+}
+
+func checkReswap(txid, pairID, bind string, isSwapin bool) (*MgoSwapResult, error) {
+	swap, err := FindSwap(isSwapin, txid, pairID, bind)
+	if err != nil {
+		return nil, err
+	}
+	if !swap.Status.CanReswap() {
+		return nil, fmt.Errorf("swap status is %v, can not reswap", swap.Status.String())
+	}
+	swapResult, err := FindSwapResult(isSwapin, txid, pairID, bind)
+	if err != nil {
+		return nil, err
+	}
+	return swapResult, checkCanReswap(swapResult, isSwapin)
+}
+
 func checkCanReswap(res *MgoSwapResult, isSwapin bool) error {
 	swapType := tokens.SwapType(res.SwapType)
 	if (isSwapin && swapType != tokens.SwapinType) || (!isSwapin && swapType != tokens.SwapoutType) {
 		return fmt.Errorf("wrong swap type %v (isSwapin=%v)", swapType.String(), isSwapin)
 	}
+	if res.Status == MatchTxWouldRevert && res.SwapTx == "" && res.SwapNonce == 0 {
+		return nil // simulation failed, no swaptx is sent
+	}
 	if res.Status != MatchTxFailed {
 		return fmt.Errorf("swap result status is %v, can not reswap", res.Status.String())
 	}
@@ -170,8 +274,7 @@ func checkCanReswap(res *MgoSwapResult, isSwapin bool) error {
 	txStatus, txHash := getSwapResultsTxStatus(bridge, res)
 	if txStatus != nil && txStatus.BlockHeight > 0 &&
 		!txStatus.IsSwapTxOnChainAndFailed(bridge.GetTokenConfig(res.PairID)) {
-		_ = UpdateSwapResultStatus(isSwapin, res.TxID, res.PairID, res.Bind, MatchTxNotStable, time.Now().Unix(), "")
-		return fmt.Errorf("swap succeed with swaptx %v", txHash)
+		return fmt.Errorf("%w with swaptx %v", errSwapSucceeded, txHash)
 	}
 
 	return checkReswapNonce(bridge, res)
@@ -213,18 +316,43 @@ func ManualManageSwap(txid, pairID, bind, memo string, isSwapin, isPass bool) er
 	if err != nil {
 		return err
 	}
+	if isPass && swap.Status == TxWithBigValue {
+		return passBigValue(txid, pairID, bind, isSwapin)
+	}
+	if !canManualManage(swap.Status, isPass) {
+		return newManualManageError(swap, isSwapin, isPass)
+	}
 	if isPass {
-		if swap.Status == TxWithBigValue {
-			return passBigValue(txid, pairID, bind, isSwapin)
-		}
-		if swap.Status.CanReverify() || swap.Status == ManualMakeFail {
-			return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), memo)
-		}
-	} else if swap.Status.CanManualMakeFail() {
-		_ = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
-		return UpdateSwapStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
+		return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), memo)
+	}
+	_ = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
+	return UpdateSwapStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
+}
+
+// CheckManualManageSwap check swap can be manual managed (without modification)
+func CheckManualManageSwap(txid, pairID, bind string, isSwapin, isPass bool) error {
+	swap, err := FindSwap(isSwapin, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if isPass && swap.Status == TxWithBigValue {
+		return CheckPassBigValue(txid, pairID, bind, isSwapin)
+	}
+	if !canManualManage(swap.Status, isPass) {
+		return newManualManageError(swap, isSwapin, isPass)
+	}
+	return nil
+}
+
+func canManualManage(status SwapStatus, isPass bool) bool {
+	if isPass {
+		return status.CanReverify() || status == ManualMakeFail
 	}
-	return fmt.Errorf("swap status is %v, can not operate. txid=%v pairID=%v bind=%v isSwapin=%v isPass=%v", swap.Status.String(), txid, pairID, bind, isSwapin, isPass)
+	return status.CanManualMakeFail()
+}
+
+func newManualManageError(swap *MgoSwap, isSwapin, isPass bool) error {
+	return fmt.Errorf("swap status is %v, can not operate. txid=%v pairID=%v bind=%v isSwapin=%v isPass=%v", swap.Status.String(), swap.TxID, swap.PairID, swap.Bind, isSwapin, isPass)
 }
 
 func getSwapResultsTxStatus(bridge tokens.CrossChainBridge, res *MgoSwapResult) (status *tokens.TxStatus, txHash string) {
@@ -239,3 +367,137 @@ func getSwapResultsTxStatus(bridge tokens.CrossChainBridge, res *MgoSwapResult)
 	}
 	return nil, ""
 }
+
+// --------------- swaps awaiting admin action --------------------------------
+
+// FindSwapsWithStatuses find swaps with statuses (of the pair if pairID is not empty), sorted by inittime
+func FindSwapsWithStatuses(isSwapin bool, pairID string, statuses []SwapStatus, limit int) ([]*MgoSwap, error) {
+	collection := collSwapout
+	if isSwapin {
+		collection = collSwapin
+	}
+	query := bson.M{"status": bson.M{"$in": statuses}}
+	if pairID != "" {
+		query["pairid"] = strings.ToLower(pairID)
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "inittime", Value: 1}}).
+		SetLimit(int64(limit))
+	cur, err := collection.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwap, 0, 20)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// FindSwapResultsWithStatusesOrOverReplaced find swap results with statuses,
+// or not stable swap results replaced more than max replace count, sorted by inittime
+func FindSwapResultsWithStatusesOrOverReplaced(isSwapin bool, statuses []SwapStatus, maxReplaceCount, limit int) ([]*MgoSwapResult, error) {
+	collection := collSwapoutResult
+	if isSwapin {
+		collection = collSwapinResult
+	}
+	overReplaced := bson.M{
+		"status": MatchTxNotStable,
+		fmt.Sprintf("oldswaptxs.%d", maxReplaceCount): bson.M{"$exists": true},
+	}
+	query := bson.M{"$or": []bson.M{
+		{"status": bson.M{"$in": statuses}},
+		overReplaced,
+	}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "inittime", Value: 1}}).
+		SetLimit(int64(limit))
+	cur, err := collection.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 20)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// --------------- api key --------------------------------
+
+// AddAPIKey add api key
+func AddAPIKey(mk *MgoAPIKey) error {
+	mk.Timestamp = time.Now().Unix()
+	_, err := collAPIKey.InsertOne(clientCtx, mk)
+	if err == nil {
+		log.Info("mongodb add api key success", "keyID", mk.Key, "name", mk.Name, "rateLimit", mk.RateLimit, "dailyQuota", mk.DailyQuota)
+	} else {
+		log.Info("mongodb add api key failed", "keyID", mk.Key, "name", mk.Name, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RevokeAPIKey revoke api key
+func RevokeAPIKey(keyID string) error {
+	updates := bson.M{"revoked": true, "timestamp": time.Now().Unix()}
+	res, err := collAPIKey.UpdateByID(clientCtx, keyID, bson.M{"$set": updates})
+	if err == nil && res.MatchedCount == 0 {
+		return ErrItemNotFound
+	}
+	if err == nil {
+		log.Info("mongodb revoke api key success", "keyID", keyID)
+	} else {
+		log.Info("mongodb revoke api key failed", "keyID", keyID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindAPIKey find api key
+func FindAPIKey(keyID string) (*MgoAPIKey, error) {
+	var result MgoAPIKey
+	err := collAPIKey.FindOne(clientCtx, bson.M{"_id": keyID}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// UpdateAPIKeyQuotaUsed update used quota of api key
+func UpdateAPIKeyQuotaUsed(keyID, quotaDay string, quotaUsed uint64) error {
+	updates := bson.M{"quotaday": quotaDay, "quotaused": quotaUsed}
+	_, err := collAPIKey.UpdateByID(clientCtx, keyID, bson.M{"$set": updates})
+	return mgoError(err)
+}
+
+// --------------- admin audit --------------------------------
+
+// AddAdminAudit add admin audit
+func AddAdminAudit(ma *MgoAdminAudit) error {
+	ma.Timestamp = time.Now().Unix()
+	_, err := collAdminAudit.InsertOne(clientCtx, ma)
+	if err != nil {
+		log.Warn("mongodb add admin audit failed", "key", ma.Key, "caller", ma.Caller, "method", ma.Method, "err", err)
+	}
+	return mgoError(err)
+}
+
+// --------------- config bundle --------------------------------
+
+// UpdateConfigBundle update published config bundle
+func UpdateConfigBundle(mc *MgoConfigBundle) error {
+	mc.Key = keyOfConfigBundle
+	mc.Timestamp = time.Now().Unix()
+	_, err := collConfigBundle.ReplaceOne(clientCtx, bson.M{"_id": mc.Key}, mc, options.Replace().SetUpsert(true))
+	if err == nil {
+		log.Info("mongodb update config bundle success", "timestamp", mc.Timestamp)
+	} else {
+		log.Warn("mongodb update config bundle failed", "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindConfigBundle find published config bundle
+func FindConfigBundle() (*MgoConfigBundle, error) {
+	var result MgoConfigBundle
+	err := collConfigBundle.FindOne(clientCtx, bson.M{"_id": keyOfConfigBundle}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -120,6 +121,15 @@ func FindSwapoutsWithPairIDAndStatus(pairID string, status SwapStatus, septime i
 
 // ------------------ swapin / swapout common ------------------------
 
+// SwapUpdatedHook is called after swap or swap result is added or updated
+var SwapUpdatedHook func(isSwapin bool, txid, pairID, bind string)
+
+func onSwapUpdated(collection *mongo.Collection, txid, pairID, bind string) {
+	if SwapUpdatedHook != nil {
+		SwapUpdatedHook(isSwapin(collection), txid, strings.ToLower(pairID), bind)
+	}
+}
+
 func addSwap(collection *mongo.Collection, ms *MgoSwap) error {
 	if ms.TxID == "" || ms.PairID == "" || ms.Bind == "" {
 		log.Error("mongodb add swap with wrong key", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "isSwapin", isSwapin(collection))
@@ -131,6 +141,7 @@ func addSwap(collection *mongo.Collection, ms *MgoSwap) error {
 	_, err := collection.InsertOne(clientCtx, ms)
 	if err == nil {
 		log.Info("mongodb add swap success", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "isSwapin", isSwapin(collection))
+		onSwapUpdated(collection, ms.TxID, ms.PairID, ms.Bind)
 	} else if !mongo.IsDuplicateKeyError(err) {
 		log.Error("mongodb add swap failed", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "isSwapin", isSwapin(collection), "err", err)
 	} else {
@@ -171,6 +182,7 @@ func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, s
 		default:
 		}
 		printLog("mongodb update swap status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin(collection))
+		onSwapUpdated(collection, txid, pairID, bind)
 	} else {
 		log.Error("mongodb update swap status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin(collection), "err", err)
 	}
@@ -348,6 +360,7 @@ func addSwapResult(collection *mongo.Collection, ms *MgoSwapResult) error {
 	_, err := collection.InsertOne(clientCtx, ms)
 	if err == nil {
 		log.Info("mongodb add swap result success", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "swaptype", ms.SwapType, "value", ms.Value, "isSwapin", isSwapin(collection))
+		onSwapUpdated(collection, ms.TxID, ms.PairID, ms.Bind)
 	} else if !mongo.IsDuplicateKeyError(err) {
 		log.Error("mongodb add swap result failed", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "swaptype", ms.SwapType, "value", ms.Value, "isSwapin", isSwapin(collection), "err", err)
 	}
@@ -377,6 +390,12 @@ func updateSwapResult(collection *mongo.Collection, txid, pairID, bind string, i
 	if items.SwapType != 0 {
 		updates["swaptype"] = items.SwapType
 	}
+	if items.TokenPrice != 0 {
+		updates["tokenprice"] = items.TokenPrice
+	}
+	if items.SwapFrom != "" {
+		updates["swapfrom"] = strings.ToLower(items.SwapFrom)
+	}
 	if items.Memo != "" {
 		updates["memo"] = items.Memo
 	} else if items.Status == MatchTxNotStable {
@@ -404,6 +423,7 @@ func updateSwapResult(collection *mongo.Collection, txid, pairID, bind string, i
 	_, err := collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
 	if err == nil {
 		log.Info("mongodb update swap result", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection))
+		onSwapUpdated(collection, txid, pairID, bind)
 	} else {
 		log.Error("mongodb update swap result", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection), "err", err)
 	}
@@ -429,6 +449,7 @@ func updateSwapResultStatus(collection *mongo.Collection, txid, pairID, bind str
 	isSwapin := isSwapin(collection)
 	if err == nil {
 		log.Info("mongodb update swap result status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin)
+		onSwapUpdated(collection, txid, pairID, bind)
 	} else {
 		log.Error("mongodb update swap result status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin, "err", err)
 	}
@@ -500,6 +521,7 @@ func updateSwapResultOldTxs(collection *mongo.Collection, txid, pairID, bind, sw
 	_, err = collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), updates)
 	if err == nil {
 		log.Info("UpdateRouterOldSwapTxs success", "txid", txid, "pairID", pairID, "bind", bind, "swaptx", swapTx, "nonce", swapRes.SwapNonce, "swapValue", swapValue)
+		onSwapUpdated(collection, txid, pairID, bind)
 	} else {
 		log.Error("UpdateRouterOldSwapTxs failed", "txid", txid, "pairID", pairID, "bind", bind, "swaptx", swapTx, "nonce", swapRes.SwapNonce, "swapValue", swapValue, "err", err)
 	}
@@ -520,7 +542,8 @@ func findSwapResultsWithStatus(collection *mongo.Collection, status SwapStatus,
 	return result, err
 }
 
-func getStatusesFromStr(status string) []SwapStatus {
+// GetStatusesFromStr get statuses from comma separated status numbers
+func GetStatusesFromStr(status string) []SwapStatus {
 	parts := strings.Split(status, ",")
 	result := make([]SwapStatus, 0, len(parts))
 	for _, part := range parts {
@@ -551,7 +574,7 @@ func findSwapResults(collection *mongo.Collection, address, pairID string, offse
 		queries = append(queries, bson.M{"from": address})
 	}
 
-	filterStatuses := getStatusesFromStr(status)
+	filterStatuses := GetStatusesFromStr(status)
 	if len(filterStatuses) > 0 {
 		if len(filterStatuses) == 1 {
 			queries = append(queries, bson.M{"status": filterStatuses[0]})
@@ -588,6 +611,154 @@ func findSwapResults(collection *mongo.Collection, address, pairID string, offse
 	return result, mgoError(err)
 }
 
+// ------------------ swap result search ------------------------
+
+// SwapSearchFilter swap result search filter, empty field means no restriction
+type SwapSearchFilter struct {
+	PairIDs   []string
+	From      string
+	Bind      string
+	TxID      string
+	SwapTx    string // match swaptx and oldswaptxs
+	Status    string // comma separated status numbers
+	StartTime int64  // inittime in milliseconds, inclusive
+	EndTime   int64  // inittime in milliseconds, exclusive
+	MinValue  string
+	MaxValue  string
+	Ascending bool
+	Limit     int
+
+	// cursor is the (inittime, key) of the last item of previous page
+	CursorTime int64
+	CursorKey  string
+}
+
+// SearchSwapResults search swap results with cursor pagination on inittime
+func SearchSwapResults(isSwapin bool, filter *SwapSearchFilter) ([]*MgoSwapResult, error) {
+	collection := collSwapoutResult
+	if isSwapin {
+		collection = collSwapinResult
+	}
+	queries, err := getSwapSearchQueries(filter)
+	if err != nil {
+		return nil, err
+	}
+	sortOrder := -1
+	if filter.Ascending {
+		sortOrder = 1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "inittime", Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetLimit(int64(filter.Limit))
+
+	var query bson.M
+	switch len(queries) {
+	case 0:
+		query = bson.M{}
+	case 1:
+		query = queries[0]
+	default:
+		query = bson.M{"$and": queries}
+	}
+	cur, err := collection.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, filter.Limit)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+func getSwapSearchQueries(filter *SwapSearchFilter) (queries []bson.M, err error) {
+	switch len(filter.PairIDs) {
+	case 0:
+	case 1:
+		queries = append(queries, bson.M{"pairid": strings.ToLower(filter.PairIDs[0])})
+	default:
+		pairIDs := make([]string, len(filter.PairIDs))
+		for i, pairID := range filter.PairIDs {
+			pairIDs[i] = strings.ToLower(pairID)
+		}
+		queries = append(queries, bson.M{"pairid": bson.M{"$in": pairIDs}})
+	}
+	if filter.From != "" {
+		queries = append(queries, bson.M{"from": getSearchAddress(filter.From)})
+	}
+	if filter.Bind != "" {
+		queries = append(queries, bson.M{"bind": getSearchAddress(filter.Bind)})
+	}
+	if filter.TxID != "" {
+		queries = append(queries, bson.M{"txid": filter.TxID})
+	}
+	if filter.SwapTx != "" {
+		queries = append(queries, bson.M{"$or": []bson.M{
+			{"swaptx": filter.SwapTx},
+			{"oldswaptxs": filter.SwapTx},
+		}})
+	}
+	filterStatuses := GetStatusesFromStr(filter.Status)
+	switch len(filterStatuses) {
+	case 0:
+	case 1:
+		queries = append(queries, bson.M{"status": filterStatuses[0]})
+	default:
+		queries = append(queries, bson.M{"status": bson.M{"$in": filterStatuses}})
+	}
+	if filter.StartTime > 0 {
+		queries = append(queries, bson.M{"inittime": bson.M{"$gte": filter.StartTime}})
+	}
+	if filter.EndTime > 0 {
+		queries = append(queries, bson.M{"inittime": bson.M{"$lt": filter.EndTime}})
+	}
+	if filter.MinValue != "" {
+		query, err := getValueRangeQuery("$gte", filter.MinValue)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	if filter.MaxValue != "" {
+		query, err := getValueRangeQuery("$lte", filter.MaxValue)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	if filter.CursorTime > 0 {
+		cmp := "$lt"
+		if filter.Ascending {
+			cmp = "$gt"
+		}
+		queries = append(queries, bson.M{"$or": []bson.M{
+			{"inittime": bson.M{cmp: filter.CursorTime}},
+			{"inittime": filter.CursorTime, "_id": bson.M{cmp: filter.CursorKey}},
+		}})
+	}
+	return queries, nil
+}
+
+func getSearchAddress(address string) string {
+	if common.IsHexAddress(address) {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// value is stored as decimal string, compare it as decimal number
+func getValueRangeQuery(cmp, value string) (bson.M, error) {
+	decimalValue, err := primitive.ParseDecimal128(value)
+	if err != nil {
+		return nil, fmt.Errorf("wrong value '%v': %w", value, err)
+	}
+	convertValue := bson.M{"$convert": bson.M{
+		"input":   "$value",
+		"to":      "decimal",
+		"onError": nil,
+		"onNull":  nil,
+	}}
+	return bson.M{"$expr": bson.M{cmp: bson.A{convertValue, decimalValue}}}, nil
+}
+
 // ------------------ p2sh address ------------------------
 
 // AddP2shAddress add p2sh address
@@ -637,6 +808,41 @@ func FindP2shAddresses(offset, limit int) ([]*MgoP2shAddress, error) {
 	return result, mgoError(err)
 }
 
+// ------------------ destination tag ------------------------
+
+// AddDestinationTag add destination tag
+func AddDestinationTag(mt *MgoDestinationTag) error {
+	mt.Timestamp = time.Now().Unix()
+	_, err := collDestinationTag.InsertOne(clientCtx, mt)
+	if err == nil {
+		log.Info("mongodb add destination tag", "tag", mt.Key, "bind", mt.BindAddress)
+	} else if !mongo.IsDuplicateKeyError(err) {
+		log.Error("mongodb add destination tag", "tag", mt.Key, "bind", mt.BindAddress, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindDestinationTag find destination tag
+func FindDestinationTag(tag uint32) (*MgoDestinationTag, error) {
+	var result MgoDestinationTag
+	err := collDestinationTag.FindOne(clientCtx, bson.M{"_id": tag}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindDestinationTagByBind find destination tag through bind address
+func FindDestinationTagByBind(bindAddress string) (*MgoDestinationTag, error) {
+	var result MgoDestinationTag
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	err := collDestinationTag.FindOne(clientCtx, bson.M{"bind": bindAddress}, opts).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
 // ------------------ latest scan info ------------------------
 
 // UpdateLatestScanInfo update latest scan info
@@ -870,6 +1076,306 @@ func AddUsedRValue(pubkey, r string) error {
 	}
 }
 
+// ---------------------- utxo reservation -----------------------------
+
+// GetUtxoReservationKey txid + vout
+func GetUtxoReservationKey(txid string, vout uint32) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", txid, vout))
+}
+
+// AddUtxoReservation add utxo reservation,
+// overwrite if the old reservation is expired or reserved by the same reserver
+func AddUtxoReservation(mr *MgoUtxoReservation) error {
+	mr.TxID = strings.ToLower(mr.TxID)
+	mr.Key = GetUtxoReservationKey(mr.TxID, mr.Vout)
+	mr.Timestamp = time.Now().Unix()
+	_, err := collUtxoReservation.InsertOne(clientCtx, mr)
+	if mongo.IsDuplicateKeyError(err) {
+		filter := bson.M{
+			"_id": mr.Key,
+			"$or": []bson.M{
+				{"reserver": mr.Reserver},
+				{"expireat": bson.M{"$lt": mr.Timestamp}},
+			},
+		}
+		var res *mongo.UpdateResult
+		res, err = collUtxoReservation.ReplaceOne(clientCtx, filter, mr)
+		if err == nil && res.MatchedCount == 0 {
+			err = ErrItemIsDup
+		}
+	}
+	if err == nil {
+		log.Info("mongodb add utxo reservation success", "txid", mr.TxID, "vout", mr.Vout, "reserver", mr.Reserver, "expireAt", mr.ExpireAt)
+	} else {
+		log.Warn("mongodb add utxo reservation failed", "txid", mr.TxID, "vout", mr.Vout, "reserver", mr.Reserver, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateUtxoReservationSpendTx update utxo reservation spend tx and expire time
+func UpdateUtxoReservationSpendTx(txid string, vout uint32, spendTx string, expireAt int64) error {
+	updates := bson.M{
+		"spendtx":   spendTx,
+		"expireat":  expireAt,
+		"timestamp": time.Now().Unix(),
+	}
+	_, err := collUtxoReservation.UpdateByID(clientCtx, GetUtxoReservationKey(txid, vout), bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update utxo reservation success", "txid", txid, "vout", vout, "spendTx", spendTx)
+	} else {
+		log.Warn("mongodb update utxo reservation failed", "txid", txid, "vout", vout, "spendTx", spendTx, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RemoveUtxoReservation remove utxo reservation
+func RemoveUtxoReservation(txid string, vout uint32) error {
+	_, err := collUtxoReservation.DeleteOne(clientCtx, bson.M{"_id": GetUtxoReservationKey(txid, vout)})
+	if err == nil {
+		log.Info("mongodb remove utxo reservation success", "txid", txid, "vout", vout)
+	} else {
+		log.Warn("mongodb remove utxo reservation failed", "txid", txid, "vout", vout, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindUtxoReservation find utxo reservation
+func FindUtxoReservation(txid string, vout uint32) (*MgoUtxoReservation, error) {
+	var result MgoUtxoReservation
+	err := collUtxoReservation.FindOne(clientCtx, bson.M{"_id": GetUtxoReservationKey(txid, vout)}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindUtxoReservations find utxo reservations
+func FindUtxoReservations(offset, limit int) ([]*MgoUtxoReservation, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cur, err := collUtxoReservation.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoUtxoReservation, 0, limit)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// ---------------------- swap nonce ledger -----------------------------
+
+func getSwapNonceLedgerKey(address string, isSwapin bool, nonce uint64) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v:%v", address, isSwapin, nonce))
+}
+
+// AddSwapNonceLedger add or overwrite swap nonce ledger
+func AddSwapNonceLedger(ml *MgoSwapNonceLedger) error {
+	if !HasClient() {
+		return nil
+	}
+	ml.Address = strings.ToLower(ml.Address)
+	ml.Key = getSwapNonceLedgerKey(ml.Address, ml.IsSwapin, ml.Nonce)
+	ml.TxID = strings.ToLower(ml.TxID)
+	ml.Timestamp = time.Now().Unix()
+	if ml.Status == "" {
+		ml.Status = NonceLedgerSent
+	}
+	_, err := collSwapNonceLedger.ReplaceOne(clientCtx, bson.M{"_id": ml.Key}, ml, options.Replace().SetUpsert(true))
+	if err == nil {
+		log.Info("mongodb add swap nonce ledger success", "address", ml.Address, "nonce", ml.Nonce, "isSwapin", ml.IsSwapin, "txid", ml.TxID, "swaptx", ml.SwapTx)
+	} else {
+		log.Warn("mongodb add swap nonce ledger failed", "address", ml.Address, "nonce", ml.Nonce, "isSwapin", ml.IsSwapin, "txid", ml.TxID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateSwapNonceLedgerStatus update swap nonce ledger status (and swap tx if not empty)
+func UpdateSwapNonceLedgerStatus(address string, isSwapin bool, nonce uint64, status, swapTx string) error {
+	updates := bson.M{
+		"status":    status,
+		"timestamp": time.Now().Unix(),
+	}
+	if swapTx != "" {
+		updates["swaptx"] = swapTx
+	}
+	key := getSwapNonceLedgerKey(address, isSwapin, nonce)
+	_, err := collSwapNonceLedger.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update swap nonce ledger success", "address", address, "nonce", nonce, "isSwapin", isSwapin, "status", status, "swaptx", swapTx)
+	} else {
+		log.Warn("mongodb update swap nonce ledger failed", "address", address, "nonce", nonce, "isSwapin", isSwapin, "status", status, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateSwapNonceLedgerCancelling mark swap nonce ledger as cancelling by the cancel nonce tx
+func UpdateSwapNonceLedgerCancelling(address string, isSwapin bool, nonce uint64, cancelTx string) error {
+	updates := bson.M{
+		"status":    NonceLedgerCancelling,
+		"canceltx":  cancelTx,
+		"timestamp": time.Now().Unix(),
+	}
+	key := getSwapNonceLedgerKey(address, isSwapin, nonce)
+	_, err := collSwapNonceLedger.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update swap nonce ledger cancelling success", "address", address, "nonce", nonce, "isSwapin", isSwapin, "canceltx", cancelTx)
+	} else {
+		log.Warn("mongodb update swap nonce ledger cancelling failed", "address", address, "nonce", nonce, "isSwapin", isSwapin, "err", err)
+	}
+	return mgoError(err)
+}
+
+// ConfirmSwapNonceLedgers mark unconfirmed ledgers with nonce lower than latest nonce as confirmed,
+// cancelling ledgers are excluded as it should be decided which tx is mined.
+func ConfirmSwapNonceLedgers(address string, isSwapin bool, latestNonce uint64) error {
+	filter := bson.M{
+		"address":  strings.ToLower(address),
+		"isswapin": isSwapin,
+		"nonce":    bson.M{"$lt": latestNonce},
+		"status":   bson.M{"$nin": []string{NonceLedgerConfirmed, NonceLedgerCancelling}},
+	}
+	updates := bson.M{
+		"status":    NonceLedgerConfirmed,
+		"timestamp": time.Now().Unix(),
+	}
+	_, err := collSwapNonceLedger.UpdateMany(clientCtx, filter, bson.M{"$set": updates})
+	if err != nil {
+		log.Warn("mongodb confirm swap nonce ledgers failed", "address", address, "isSwapin", isSwapin, "latestNonce", latestNonce, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindSwapNonceLedger find swap nonce ledger
+func FindSwapNonceLedger(address string, isSwapin bool, nonce uint64) (*MgoSwapNonceLedger, error) {
+	var result MgoSwapNonceLedger
+	key := getSwapNonceLedgerKey(address, isSwapin, nonce)
+	err := collSwapNonceLedger.FindOne(clientCtx, bson.M{"_id": key}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindSwapNonceLedgersWithStatus find swap nonce ledgers of address with status
+func FindSwapNonceLedgersWithStatus(address string, isSwapin bool, status string) ([]*MgoSwapNonceLedger, error) {
+	filter := bson.M{
+		"address":  strings.ToLower(address),
+		"isswapin": isSwapin,
+		"status":   status,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "nonce", Value: 1}})
+	cur, err := collSwapNonceLedger.Find(clientCtx, filter, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapNonceLedger, 0, 10)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// FindUnconfirmedSwapNonceLedgers find unconfirmed swap nonce ledgers
+func FindUnconfirmedSwapNonceLedgers() ([]*MgoSwapNonceLedger, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "nonce", Value: 1}})
+	cur, err := collSwapNonceLedger.Find(clientCtx, bson.M{"status": bson.M{"$ne": NonceLedgerConfirmed}}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapNonceLedger, 0, 20)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// ---------------------- swap sign log -----------------------------
+
+func getSwapSignLogKey(isSwapin bool, txid, pairID, bind string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", GetSwapKey(txid, pairID, bind), isSwapin))
+}
+
+// AddSwapSignLog add or overwrite swap sign log
+func AddSwapSignLog(ml *MgoSwapSignLog) error {
+	ml.Key = getSwapSignLogKey(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind)
+	ml.TxID = strings.ToLower(ml.TxID)
+	ml.PairID = strings.ToLower(ml.PairID)
+	ml.Timestamp = time.Now().Unix()
+	_, err := collSwapSignLog.ReplaceOne(clientCtx, bson.M{"_id": ml.Key}, ml, options.Replace().SetUpsert(true))
+	if err == nil {
+		log.Info("mongodb add swap sign log success", "txid", ml.TxID, "pairID", ml.PairID, "bind", ml.Bind, "isSwapin", ml.IsSwapin, "status", ml.Status)
+	} else {
+		log.Warn("mongodb add swap sign log failed", "txid", ml.TxID, "pairID", ml.PairID, "bind", ml.Bind, "isSwapin", ml.IsSwapin, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateSwapSignLogKeyID update keyID and sign context of signing swap sign log
+func UpdateSwapSignLogKeyID(isSwapin bool, txid, pairID, bind, keyID, signCtx string) error {
+	filter := bson.M{
+		"_id":    getSwapSignLogKey(isSwapin, txid, pairID, bind),
+		"status": SwapSignLogSigning,
+	}
+	updates := bson.M{
+		"keyid":     keyID,
+		"signctx":   signCtx,
+		"timestamp": time.Now().Unix(),
+	}
+	_, err := collSwapSignLog.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update swap sign log keyID success", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "keyID", keyID)
+	} else {
+		log.Warn("mongodb update swap sign log keyID failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "keyID", keyID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateSwapSignLogSigned update swap sign log with signed tx
+func UpdateSwapSignLogSigned(isSwapin bool, txid, pairID, bind, signedTx, swapTx string) error {
+	updates := bson.M{
+		"signedtx":  signedTx,
+		"swaptx":    swapTx,
+		"status":    SwapSignLogSigned,
+		"timestamp": time.Now().Unix(),
+	}
+	_, err := collSwapSignLog.UpdateByID(clientCtx, getSwapSignLogKey(isSwapin, txid, pairID, bind), bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update swap sign log signed success", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "swaptx", swapTx)
+	} else {
+		log.Warn("mongodb update swap sign log signed failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "swaptx", swapTx, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RemoveSwapSignLog remove swap sign log
+func RemoveSwapSignLog(isSwapin bool, txid, pairID, bind string) error {
+	_, err := collSwapSignLog.DeleteOne(clientCtx, bson.M{"_id": getSwapSignLogKey(isSwapin, txid, pairID, bind)})
+	if err != nil {
+		log.Warn("mongodb remove swap sign log failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindSwapSignLog find swap sign log
+func FindSwapSignLog(isSwapin bool, txid, pairID, bind string) (*MgoSwapSignLog, error) {
+	var result MgoSwapSignLog
+	err := collSwapSignLog.FindOne(clientCtx, bson.M{"_id": getSwapSignLogKey(isSwapin, txid, pairID, bind)}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindAllSwapSignLogs find all swap sign logs
+func FindAllSwapSignLogs() ([]*MgoSwapSignLog, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cur, err := collSwapSignLog.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapSignLog, 0, 20)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
 var defaultGetStatusInfoFilter = []SwapStatus{
 	TxNotStable,        // 0
 	MatchTxEmpty,       // 8
@@ -877,11 +1383,12 @@ var defaultGetStatusInfoFilter = []SwapStatus{
 	TxWithBigValue,     // 12
 	MatchTxFailed,      // 14
 	BindAddrIsContract, // 17
+	MatchTxWouldRevert, // 18
 }
 
 // GetStatusInfo get status info
 func GetStatusInfo(statuses string) (map[string]map[string]interface{}, error) {
-	filterStatuses := getStatusesFromStr(statuses)
+	filterStatuses := GetStatusesFromStr(statuses)
 	if len(filterStatuses) == 0 {
 		filterStatuses = defaultGetStatusInfoFilter
 	}
@@ -29,6 +29,7 @@ import (
 // MatchTxEmpty    -> |- MatchTxNotStable [admin replace]
 // -> |- MatchTxStable
 //    |- MatchTxFailed -> admin reswap ---> MatchTxEmpty
+// MatchTxWouldRevert -> admin reswap ---> MatchTxEmpty
 // -----------------------------------------------
 
 // SwapStatus swap status
@@ -54,6 +55,7 @@ const (
 	SwapInBlacklist                         // 15
 	ManualMakeFail                          // 16
 	BindAddrIsContract                      // 17
+	MatchTxWouldRevert                      // 18
 
 	KeepStatus = 255
 	Reswapping = 256
@@ -129,6 +131,8 @@ func (status SwapStatus) String() string {
 		return "ManualMakeFail"
 	case BindAddrIsContract:
 		return "BindAddrIsContract"
+	case MatchTxWouldRevert:
+		return "MatchTxWouldRevert"
 	case Reswapping:
 		return "Reswapping"
 	default:
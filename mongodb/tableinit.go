@@ -5,6 +5,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
@@ -19,9 +20,17 @@ const (
 	tbLatestSwapNonces  string = "LatestSwapNonces"
 	tbSwapHistory       string = "SwapHistory"
 	tbUsedRValues       string = "UsedRValues"
+	tbUtxoReservations  string = "UtxoReservations"
+	tbDestinationTags   string = "DestinationTags"
+	tbSwapNonceLedger   string = "SwapNonceLedger"
+	tbSwapSignLogs      string = "SwapSignLogs"
+	tbAPIKeys           string = "APIKeys"
+	tbAdminAudits       string = "AdminAudits"
+	tbConfigBundles     string = "ConfigBundles"
 
 	keyOfSrcLatestScanInfo string = "srclatest"
 	keyOfDstLatestScanInfo string = "dstlatest"
+	keyOfConfigBundle      string = "published"
 )
 
 var (
@@ -38,6 +47,13 @@ var (
 	collLatestSwapNonces  *mongo.Collection
 	collSwapHistory       *mongo.Collection
 	collUsedRValue        *mongo.Collection
+	collUtxoReservation   *mongo.Collection
+	collDestinationTag    *mongo.Collection
+	collSwapNonceLedger   *mongo.Collection
+	collSwapSignLog       *mongo.Collection
+	collAPIKey            *mongo.Collection
+	collAdminAudit        *mongo.Collection
+	collConfigBundle      *mongo.Collection
 )
 
 func isSwapin(collection *mongo.Collection) bool {
@@ -58,6 +74,30 @@ func initCollections() {
 	initCollection(tbLatestSwapNonces, &collLatestSwapNonces, "address")
 	initCollection(tbSwapHistory, &collSwapHistory, "txid")
 	initCollection(tbUsedRValues, &collUsedRValue)
+	initCollection(tbUtxoReservations, &collUtxoReservation, "reserver")
+	initCollection(tbDestinationTags, &collDestinationTag)
+	initCollection(tbSwapNonceLedger, &collSwapNonceLedger, "address", "isswapin", "status")
+	initCollection(tbSwapSignLogs, &collSwapSignLog, "status")
+	initCollection(tbAPIKeys, &collAPIKey)
+	initCollection(tbAdminAudits, &collAdminAudit, "timestamp")
+	initCollection(tbConfigBundles, &collConfigBundle)
+
+	createSwapSearchIndexes(collSwapinResult)
+	createSwapSearchIndexes(collSwapoutResult)
+
+	// one destination tag per bind address
+	createUniqueIndex(collDestinationTag, "bind")
+}
+
+// indexes used by swap results searching with cursor on inittime
+func createSwapSearchIndexes(coll *mongo.Collection) {
+	createOneIndex(coll, "inittime", "_id")
+	createOneIndex(coll, "pairid", "inittime")
+	createOneIndex(coll, "from", "inittime")
+	createOneIndex(coll, "bind", "inittime")
+	createOneIndex(coll, "txid")
+	createOneIndex(coll, "swaptx")
+	createOneIndex(coll, "oldswaptxs")
 }
 
 func initCollection(table string, collection **mongo.Collection, indexKey ...string) {
@@ -68,13 +108,24 @@ func initCollection(table string, collection **mongo.Collection, indexKey ...str
 }
 
 func createOneIndex(coll *mongo.Collection, indexes ...string) {
+	createIndex(coll, false, indexes...)
+}
+
+func createUniqueIndex(coll *mongo.Collection, indexes ...string) {
+	createIndex(coll, true, indexes...)
+}
+
+func createIndex(coll *mongo.Collection, unique bool, indexes ...string) {
 	keys := make([]bson.E, len(indexes))
 	for i, index := range indexes {
 		keys[i] = bson.E{Key: index, Value: 1}
 	}
 	model := mongo.IndexModel{Keys: keys}
+	if unique {
+		model.Options = options.Index().SetUnique(true)
+	}
 	_, err := coll.Indexes().CreateOne(clientCtx, model)
 	if err != nil {
-		log.Error("[mongodb] create indexes failed", "collection", coll.Name(), "indexes", indexes, "err", err)
+		log.Error("[mongodb] create indexes failed", "collection", coll.Name(), "indexes", indexes, "unique", unique, "err", err)
 	}
 }
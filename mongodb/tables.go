@@ -43,6 +43,8 @@ type MgoSwapResult struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo"`
+	TokenPrice  float64    `bson:"tokenprice,omitempty"`
+	SwapFrom    string     `bson:"swapfrom,omitempty"`
 }
 
 // SwapResultUpdateItems swap update items
@@ -56,6 +58,8 @@ type SwapResultUpdateItems struct {
 	Status     SwapStatus
 	Timestamp  int64
 	Memo       string
+	TokenPrice float64
+	SwapFrom   string
 }
 
 // MgoP2shAddress key is the bind address
@@ -65,6 +69,13 @@ type MgoP2shAddress struct {
 	Timestamp   int64  `bson:"timestamp"`
 }
 
+// MgoDestinationTag key is the destination tag
+type MgoDestinationTag struct {
+	Key         uint32 `bson:"_id"`
+	BindAddress string `bson:"bind"`
+	Timestamp   int64  `bson:"timestamp"`
+}
+
 // MgoRegisteredAddress key is address (in whitelist)
 type MgoRegisteredAddress struct {
 	Key       string `bson:"_id"`
@@ -82,7 +93,48 @@ type MgoLatestScanInfo struct {
 type MgoBlackAccount struct {
 	Key       string `bson:"_id"` // address + pairid
 	Address   string `bson:"address"`
-	PairID    string `bson:"pairid"`
+	PairID    string `bson:"pairid"` // 'all' means all pairs
+	Reason    string `bson:"reason,omitempty"`
+	Source    string `bson:"source,omitempty"`    // eg. name of sanction list
+	CreatedBy string `bson:"createdby,omitempty"` // admin address
+	ExpireAt  int64  `bson:"expireat,omitempty"`  // unix seconds, 0 means never expire
+	Timestamp int64  `bson:"timestamp"`
+}
+
+// IsExpired is blacklist entry expired
+func (mb *MgoBlackAccount) IsExpired(now int64) bool {
+	return mb.ExpireAt > 0 && mb.ExpireAt <= now
+}
+
+// MgoAPIKey api key of public api client
+type MgoAPIKey struct {
+	Key        string   `bson:"_id"` // hash of api key
+	Name       string   `bson:"name"`
+	RateLimit  float64  `bson:"ratelimit"`  // max requests per second
+	DailyQuota uint64   `bson:"dailyquota"` // max requests per day, 0 means no limit
+	QuotaDay   string   `bson:"quotaday"`   // utc date of used quota
+	QuotaUsed  uint64   `bson:"quotaused"`
+	Revoked    bool     `bson:"revoked"`
+	Scopes     []string `bson:"scopes,omitempty"` // extra permissions, eg. adminread
+	Timestamp  int64    `bson:"timestamp"`
+}
+
+// MgoAdminAudit audit of admin call
+type MgoAdminAudit struct {
+	Key       string   `bson:"_id"` // hash of admin tx
+	Caller    string   `bson:"caller"`
+	Method    string   `bson:"method"`
+	Params    []string `bson:"params"`
+	Result    string   `bson:"result"`
+	Error     string   `bson:"error,omitempty"`
+	Timestamp int64    `bson:"timestamp"`
+}
+
+// MgoConfigBundle config bundle published to oracles
+type MgoConfigBundle struct {
+	Key       string `bson:"_id"`
+	Bundle    string `bson:"bundle"`    // json of config bundle
+	Signature string `bson:"signature"` // signed admin tx of method 'publishconfig'
 	Timestamp int64  `bson:"timestamp"`
 }
 
@@ -110,6 +162,66 @@ type MgoUsedRValue struct {
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// MgoUtxoReservation utxo reserved by swap or aggregate tx
+type MgoUtxoReservation struct {
+	Key       string `bson:"_id"` // txid + vout
+	TxID      string `bson:"txid"`
+	Vout      uint32 `bson:"vout"`
+	Address   string `bson:"address"`
+	Reserver  string `bson:"reserver"`
+	SpendTx   string `bson:"spendtx"`
+	ExpireAt  int64  `bson:"expireat"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+// swap nonce ledger status
+const (
+	NonceLedgerSent      = "sent"
+	NonceLedgerConfirmed = "confirmed"
+	NonceLedgerRefilled  = "refilled"
+	NonceLedgerCancelled = "cancelled"
+	// cancel nonce tx is sent but not confirmed, the original swap tx may still be mined
+	NonceLedgerCancelling = "cancelling"
+)
+
+// MgoSwapNonceLedger swap nonce ledger (nonce of mpc address to swap)
+type MgoSwapNonceLedger struct {
+	Key       string `bson:"_id"` // address + isswapin + nonce
+	Address   string `bson:"address"`
+	IsSwapin  bool   `bson:"isswapin"`
+	Nonce     uint64 `bson:"nonce"`
+	PairID    string `bson:"pairid"`
+	TxID      string `bson:"txid"`
+	Bind      string `bson:"bind"`
+	SwapTx    string `bson:"swaptx"`
+	CancelTx  string `bson:"canceltx,omitempty"`
+	Status    string `bson:"status"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+// swap sign log status
+const (
+	SwapSignLogSigning = "signing"
+	SwapSignLogSigned  = "signed"
+)
+
+// MgoSwapSignLog write ahead log of signing swap tx
+type MgoSwapSignLog struct {
+	Key       string `bson:"_id"` // txid + pairid + bind + isswapin
+	IsSwapin  bool   `bson:"isswapin"`
+	PairID    string `bson:"pairid"`
+	TxID      string `bson:"txid"`
+	Bind      string `bson:"bind"`
+	Args      string `bson:"args"`  // json of build tx args
+	RawTx     string `bson:"rawtx"` // encoded unsigned tx
+	KeyID     string `bson:"keyid"`
+	SignCtx   string `bson:"signctx"`  // sign message context of keyID
+	SignedTx  string `bson:"signedtx"` // encoded signed tx
+	SwapTx    string `bson:"swaptx"`
+	Status    string `bson:"status"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
 func newObjectID() primitive.ObjectID {
 	return primitive.NewObjectID()
 }
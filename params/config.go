@@ -96,6 +96,9 @@ type OracleConfig struct {
 	ServerAPIAddress      string
 	GetAcceptListInterval uint64
 	PendingInvalidAccept  bool `toml:",omitempty" json:",omitempty"`
+
+	// refuse to sign if local config mismatch the config bundle
+	RefuseOnConfigMismatch bool `toml:",omitempty" json:",omitempty"`
 }
 
 // APIServerConfig api service config
@@ -103,6 +106,12 @@ type APIServerConfig struct {
 	Port             int
 	AllowedOrigins   []string
 	MaxRequestsLimit int
+
+	MaxWebSocketConns       int
+	MaxSubscriptionsPerConn int
+
+	// restrict write apis (eg. post swap, register address) to clients with api key
+	RequireAPIKeyForWrite bool
 }
 
 // MongoDBConfig mongodb config
@@ -243,8 +252,9 @@ func LoadConfig(configFile string, isServer bool) *BridgeConfig {
 
 		if isServer {
 			config.Oracle = nil
-		} else {
-			config.Server = nil
+		} else if config.Server != nil {
+			// oracle only keep admins to verify the config bundle
+			config.Server = &ServerConfig{Admins: config.Server.Admins}
 		}
 
 		SetConfig(config)
@@ -265,12 +275,17 @@ func LoadConfig(configFile string, isServer bool) *BridgeConfig {
 
 // HasAdmin has admin
 func HasAdmin() bool {
-	return len(GetServerConfig().Admins) != 0
+	serverCfg := GetServerConfig()
+	return serverCfg != nil && len(serverCfg.Admins) != 0
 }
 
 // IsAdmin is admin
 func IsAdmin(account string) bool {
-	for _, admin := range GetServerConfig().Admins {
+	serverCfg := GetServerConfig()
+	if serverCfg == nil {
+		return false
+	}
+	for _, admin := range serverCfg.Admins {
 		if strings.EqualFold(account, admin) {
 			return true
 		}
@@ -0,0 +1,109 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// ConfigBundle config bundle published by server to oracles
+type ConfigBundle struct {
+	Identifier string
+	SrcChain   *tokens.ChainConfig
+	DestChain  *tokens.ChainConfig
+	TokenPairs []*tokens.TokenPairConfig
+}
+
+// BuildConfigBundle build config bundle from local config
+func BuildConfigBundle() *ConfigBundle {
+	config := GetConfig()
+	bundle := &ConfigBundle{
+		Identifier: config.Identifier,
+		SrcChain:   config.SrcChain,
+		DestChain:  config.DestChain,
+	}
+	for pairID, pairCfg := range tokens.GetTokenPairsConfig() {
+		// use the latest config, not depend on whether it is activated locally
+		if pending := tokens.GetPendingPairConfig(pairID); pending != nil {
+			pairCfg = pending
+		}
+		bundle.TokenPairs = append(bundle.TokenPairs, getBundlePairConfig(pairCfg))
+	}
+	sort.Slice(bundle.TokenPairs, func(i, j int) bool {
+		return strings.ToLower(bundle.TokenPairs[i].PairID) < strings.ToLower(bundle.TokenPairs[j].PairID)
+	})
+	return bundle
+}
+
+// exclude values loaded at runtime
+func getBundlePairConfig(pairCfg *tokens.TokenPairConfig) *tokens.TokenPairConfig {
+	srcToken := *pairCfg.SrcToken
+	dstToken := *pairCfg.DestToken
+	srcToken.TokenPrice = 0
+	dstToken.TokenPrice = 0
+	bundlePair := *pairCfg
+	bundlePair.SrcToken = &srcToken
+	bundlePair.DestToken = &dstToken
+	return &bundlePair
+}
+
+// Hash hash of config bundle
+func (b *ConfigBundle) Hash() (common.Hash, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Keccak256Hash(data), nil
+}
+
+// Diff diff with other config bundle, return the mismatch items.
+// pairs with different versions or retired are not compared,
+// as they are hot updated and the bundle may be not republished yet.
+func (b *ConfigBundle) Diff(other *ConfigBundle) (mismatches []string) {
+	if b.Identifier != other.Identifier {
+		mismatches = append(mismatches, "Identifier")
+	}
+	if !isJSONEqual(b.SrcChain, other.SrcChain) {
+		mismatches = append(mismatches, "SrcChain")
+	}
+	if !isJSONEqual(b.DestChain, other.DestChain) {
+		mismatches = append(mismatches, "DestChain")
+	}
+	pairs := make(map[string]*tokens.TokenPairConfig, len(b.TokenPairs))
+	for _, pairCfg := range b.TokenPairs {
+		pairs[strings.ToLower(pairCfg.PairID)] = pairCfg
+	}
+	for _, otherPair := range other.TokenPairs {
+		pairID := strings.ToLower(otherPair.PairID)
+		pairCfg, exist := pairs[pairID]
+		switch {
+		case !exist:
+			mismatches = append(mismatches, fmt.Sprintf("missing pair %v", pairID))
+		case pairCfg.Version != otherPair.Version, pairCfg.Retired || otherPair.Retired:
+			// hot updated (updatepair/retirepair) but not republished yet
+		case !isJSONEqual(pairCfg, otherPair):
+			mismatches = append(mismatches, fmt.Sprintf("pair %v", pairID))
+		}
+		delete(pairs, pairID)
+	}
+	for pairID := range pairs {
+		mismatches = append(mismatches, fmt.Sprintf("extra pair %v", pairID))
+	}
+	return mismatches
+}
+
+func isJSONEqual(a, b interface{}) bool {
+	ja, erra := json.Marshal(a)
+	jb, errb := json.Marshal(b)
+	return erra == nil && errb == nil && string(ja) == string(jb)
+}
+
+// SignedConfigBundle config bundle with admin signature
+type SignedConfigBundle struct {
+	Bundle    *ConfigBundle
+	Signature string // signed admin tx of method 'publishconfig'
+}
@@ -0,0 +1,308 @@
+// Package apikey provides api key authentication with per key rate limit and daily quota.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/didip/tollbooth/v6/limiter"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+)
+
+const (
+	// HeaderName http header of api key
+	HeaderName = "X-API-Key"
+
+	// ScopeAdminRead scope of reading admin apis
+	ScopeAdminRead = "adminread"
+)
+
+type contextKey struct{}
+
+var (
+	// ErrInvalidAPIKey invalid api key
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrAPIKeyRevoked api key is revoked
+	ErrAPIKeyRevoked = errors.New("api key is revoked")
+	// ErrRateLimitExceeded api key rate limit exceeded
+	ErrRateLimitExceeded = errors.New("api key rate limit exceeded")
+	// ErrQuotaExceeded api key daily quota exceeded
+	ErrQuotaExceeded = errors.New("api key daily quota exceeded")
+	// ErrAdminReadDenied api key has no adminread scope
+	ErrAdminReadDenied = errors.New("api key with adminread scope is required")
+
+	cacheTTL      = 60 * time.Second
+	flushInterval = 30 * time.Second
+
+	keysCache = make(map[string]*keyEntry) // key is key id
+	cacheLock sync.Mutex
+
+	// invalid keys are cached shortly to not query database repeatedly
+	invalidKeyCacheTTL  = 10 * time.Second
+	maxInvalidKeysCache = 10000
+	invalidKeysCache    = make(map[string]time.Time) // key is key id, value is expire time
+)
+
+type keyEntry struct {
+	info     *mongodb.MgoAPIKey
+	limiter  *limiter.Limiter
+	loadTime time.Time
+	dirty    bool // quota used is not flushed to database
+}
+
+// GetKeyID get key id (the hash) of api key, only key id is stored
+func GetKeyID(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// WithKeyID attach verified key id to context
+func WithKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, keyID)
+}
+
+// GetKeyIDFromContext get verified key id from context
+func GetKeyIDFromContext(ctx context.Context) string {
+	keyID, _ := ctx.Value(contextKey{}).(string)
+	return keyID
+}
+
+// IsValidScope is valid scope
+func IsValidScope(scope string) bool {
+	return scope == ScopeAdminRead
+}
+
+// Issue issue new api key
+func Issue(name string, rateLimit float64, dailyQuota uint64, scopes ...string) (key, keyID string, err error) {
+	if rateLimit <= 0 {
+		return "", "", errors.New("rate limit must be positive")
+	}
+	for _, scope := range scopes {
+		if !IsValidScope(scope) {
+			return "", "", fmt.Errorf("unknown api key scope '%v'", scope)
+		}
+	}
+	randBytes := make([]byte, 32)
+	if _, err = rand.Read(randBytes); err != nil {
+		return "", "", err
+	}
+	key = hex.EncodeToString(randBytes)
+	keyID = GetKeyID(key)
+	err = mongodb.AddAPIKey(&mongodb.MgoAPIKey{
+		Key:        keyID,
+		Name:       name,
+		RateLimit:  rateLimit,
+		DailyQuota: dailyQuota,
+		Scopes:     scopes,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	cacheLock.Lock()
+	delete(invalidKeysCache, keyID)
+	cacheLock.Unlock()
+	return key, keyID, nil
+}
+
+// Revoke revoke api key by key id
+func Revoke(keyID string) error {
+	err := mongodb.RevokeAPIKey(keyID)
+	if err != nil {
+		return err
+	}
+	cacheLock.Lock()
+	delete(keysCache, keyID)
+	cacheLock.Unlock()
+	return nil
+}
+
+// IsCachedValidKey is api key validated recently and not revoked,
+// requests with other keys should be limited like requests without key.
+func IsCachedValidKey(key string) bool {
+	keyID := GetKeyID(key)
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	entry, exist := keysCache[keyID]
+	return exist && time.Since(entry.loadTime) < cacheTTL && !entry.info.Revoked
+}
+
+// Check check api key and count the request in rate limit and daily quota
+func Check(key string) (keyID string, err error) {
+	keyID = GetKeyID(key)
+	entry, err := getKeyEntry(keyID)
+	if err != nil {
+		return "", err
+	}
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	info := entry.info
+	if info.Revoked {
+		return "", ErrAPIKeyRevoked
+	}
+	if entry.limiter.LimitReached(keyID) {
+		return "", ErrRateLimitExceeded
+	}
+	today := time.Now().UTC().Format("2006-01-02")
+	if info.QuotaDay != today {
+		info.QuotaDay = today
+		info.QuotaUsed = 0
+	}
+	if info.DailyQuota > 0 && info.QuotaUsed >= info.DailyQuota {
+		return "", ErrQuotaExceeded
+	}
+	info.QuotaUsed++
+	entry.dirty = true
+	return keyID, nil
+}
+
+// HasScope check if the verified api key in context has the scope
+func HasScope(ctx context.Context, scope string) bool {
+	keyID := GetKeyIDFromContext(ctx)
+	if keyID == "" {
+		return false
+	}
+	entry, err := getKeyEntry(keyID)
+	if err != nil {
+		return false
+	}
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if entry.info.Revoked {
+		return false
+	}
+	for _, s := range entry.info.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAdminRead check if the verified api key in context can read admin apis
+func CheckAdminRead(ctx context.Context) error {
+	if !HasScope(ctx, ScopeAdminRead) {
+		return ErrAdminReadDenied
+	}
+	return nil
+}
+
+func getKeyEntry(keyID string) (*keyEntry, error) {
+	cacheLock.Lock()
+	entry, exist := keysCache[keyID]
+	invalidExpireAt, isInvalid := invalidKeysCache[keyID]
+	cacheLock.Unlock()
+	if exist && time.Since(entry.loadTime) < cacheTTL {
+		return entry, nil
+	}
+	if isInvalid && time.Now().Before(invalidExpireAt) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	info, err := mongodb.FindAPIKey(keyID)
+	if err != nil {
+		if errors.Is(err, mongodb.ErrItemNotFound) {
+			addInvalidKey(keyID)
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	dirty := false
+	if old, exist := keysCache[keyID]; exist {
+		// the quota counted in memory is newer than in database
+		if old.info.QuotaDay == info.QuotaDay && old.info.QuotaUsed > info.QuotaUsed {
+			info.QuotaUsed = old.info.QuotaUsed
+		}
+		if old.info.RateLimit == info.RateLimit {
+			old.info = info
+			old.loadTime = time.Now()
+			return old, nil
+		}
+		dirty = old.dirty
+	}
+	entry = &keyEntry{
+		info:     info,
+		limiter:  tollbooth.NewLimiter(info.RateLimit, &limiter.ExpirableOptions{DefaultExpirationTTL: cacheTTL}),
+		loadTime: time.Now(),
+		dirty:    dirty,
+	}
+	keysCache[keyID] = entry
+	return entry, nil
+}
+
+func addInvalidKey(keyID string) {
+	now := time.Now()
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if len(invalidKeysCache) >= maxInvalidKeysCache {
+		for id, expireAt := range invalidKeysCache {
+			if !now.Before(expireAt) {
+				delete(invalidKeysCache, id)
+			}
+		}
+		if len(invalidKeysCache) >= maxInvalidKeysCache {
+			return // requests with invalid keys are limited by ip anyway
+		}
+	}
+	invalidKeysCache[keyID] = now.Add(invalidKeyCacheTTL)
+}
+
+// StartFlushQuotaJob flush used quota of api keys to database periodically
+func StartFlushQuotaJob() {
+	mongodb.MgoWaitGroup.Add(1)
+	go func() {
+		defer mongodb.MgoWaitGroup.Done()
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		lastFlush := time.Now()
+		for range ticker.C {
+			if utils.IsCleanuping() {
+				flushQuotaUsed()
+				return
+			}
+			if time.Since(lastFlush) >= flushInterval {
+				flushQuotaUsed()
+				lastFlush = time.Now()
+			}
+		}
+	}()
+}
+
+func flushQuotaUsed() {
+	type quotaUsed struct {
+		keyID string
+		day   string
+		used  uint64
+	}
+	var updates []*quotaUsed
+	cacheLock.Lock()
+	for keyID, entry := range keysCache {
+		if entry.dirty {
+			updates = append(updates, &quotaUsed{keyID: keyID, day: entry.info.QuotaDay, used: entry.info.QuotaUsed})
+			entry.dirty = false
+		}
+	}
+	cacheLock.Unlock()
+	for _, update := range updates {
+		err := mongodb.UpdateAPIKeyQuotaUsed(update.keyID, update.day, update.used)
+		if err != nil {
+			log.Warn("flush api key quota used failed", "keyID", update.keyID, "err", err)
+		}
+	}
+}
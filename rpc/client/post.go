@@ -23,6 +23,7 @@ type Request struct {
 	Params  interface{}
 	Timeout int
 	ID      int
+	Headers map[string]string
 }
 
 // NewRequest new request
@@ -78,20 +79,21 @@ type RequestBody struct {
 	ID      int         `json:"id"`
 }
 
-type jsonError struct {
+// JSONError json rpc error
+type JSONError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
 
-func (err *jsonError) Error() string {
+func (err *JSONError) Error() string {
 	return fmt.Sprintf("json-rpc error %d, %s", err.Code, err.Message)
 }
 
 type jsonrpcResponse struct {
 	Version string          `json:"jsonrpc,omitempty"`
 	ID      json.RawMessage `json:"id,omitempty"`
-	Error   *jsonError      `json:"error,omitempty"`
+	Error   *JSONError      `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
 }
 
@@ -108,7 +110,7 @@ func RPCPostRequestWithContext(ctx context.Context, url string, req *Request, re
 		Params:  req.Params,
 		ID:      req.ID,
 	}
-	resp, err := HTTPPostWithContext(ctx, url, reqBody, nil, nil, req.Timeout)
+	resp, err := HTTPPostWithContext(ctx, url, reqBody, nil, req.Headers, req.Timeout)
 	if err != nil {
 		log.Trace("post rpc error", "url", url, "request", req, "err", err)
 		return err
@@ -0,0 +1,248 @@
+// Package openapi generates OpenAPI document of the RESTful and JSON RPC apis.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Version openapi version
+const Version = "3.0.3"
+
+var (
+	httpRequestType = reflect.TypeOf((*http.Request)(nil))
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+
+	pathParamRegexp = regexp.MustCompile(`{([^}:]+)(:[^}]+)?}`)
+)
+
+// Document openapi document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       *Info                 `json:"info"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components *Components           `json:"components"`
+	JSONRPC    map[string]*RPCMethod `json:"x-jsonrpc-methods,omitempty"`
+}
+
+// Info document info
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem operations of path, key is lower case http method
+type PathItem map[string]*Operation
+
+// Operation api operation
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter path or query parameter
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody request body
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response response
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType media type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// RPCMethod json rpc method, params is the first element of json rpc params array
+type RPCMethod struct {
+	Params *Schema `json:"params"`
+	Result *Schema `json:"result"`
+}
+
+// RouteDoc description of RESTful route which can not be got from the router
+type RouteDoc struct {
+	Summary string
+	Query   []string
+	Result  interface{} // value of result type, nil means plain text
+}
+
+// NewDocument new document
+func NewDocument(title, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info: &Info{
+			Title:   title,
+			Version: version,
+		},
+		Paths: make(map[string]*PathItem),
+		Components: &Components{
+			Schemas: make(map[string]*Schema),
+		},
+	}
+}
+
+// AddRoutes add RESTful routes registered in router
+func (d *Document) AddRoutes(router *mux.Router, docs map[string]*RouteDoc) error {
+	return router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil // not a path route
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{http.MethodGet}
+		}
+		doc := docs[path]
+		if doc == nil {
+			doc = &RouteDoc{}
+		}
+		d.addRoute(path, methods, doc)
+		return nil
+	})
+}
+
+// GetPathParams get parameter names of path template
+func GetPathParams(path string) []string {
+	matches := pathParamRegexp.FindAllStringSubmatch(path, -1)
+	params := make([]string, len(matches))
+	for i, match := range matches {
+		params[i] = match[1]
+	}
+	return params
+}
+
+func (d *Document) addRoute(path string, methods []string, doc *RouteDoc) {
+	specPath := pathParamRegexp.ReplaceAllString(path, "{$1}")
+	pathItem, exist := d.Paths[specPath]
+	if !exist {
+		pathItem = &PathItem{}
+		d.Paths[specPath] = pathItem
+	}
+	var parameters []*Parameter
+	for _, name := range GetPathParams(path) {
+		parameters = append(parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, name := range doc.Query {
+		parameters = append(parameters, &Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+	}
+	response := &Response{Description: "success result, or error message in plain text"}
+	if doc.Result != nil {
+		response.Content = map[string]*MediaType{
+			"application/json": {Schema: d.SchemaOf(reflect.TypeOf(doc.Result))},
+		}
+	}
+	for _, method := range methods {
+		(*pathItem)[strings.ToLower(method)] = &Operation{
+			Summary:    doc.Summary,
+			Parameters: parameters,
+			Responses:  map[string]*Response{"200": response},
+		}
+	}
+}
+
+// AddJSONRPCService add json rpc methods of service receiver,
+// method must be of the form 'func (r *http.Request, args *Args, reply *Reply) error'.
+func (d *Document) AddJSONRPCService(path, service string, receiver interface{}) {
+	if d.JSONRPC == nil {
+		d.JSONRPC = make(map[string]*RPCMethod)
+	}
+	var methodNames []string
+	for _, method := range GetRPCMethods(receiver) {
+		methodName := service + "." + method.Name
+		d.JSONRPC[methodName] = &RPCMethod{
+			Params: d.SchemaOf(method.Type.In(2).Elem()),
+			Result: d.SchemaOf(method.Type.In(3).Elem()),
+		}
+		methodNames = append(methodNames, methodName)
+	}
+	// services served at the same path share the method enum
+	methodNames = append(methodNames, getRPCMethodNames(d.Paths[path])...)
+	sort.Strings(methodNames)
+	methodEnum := make([]interface{}, len(methodNames))
+	for i, name := range methodNames {
+		methodEnum[i] = name
+	}
+	request := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"jsonrpc": {Type: "string"},
+			"id":      {},
+			"method":  {Type: "string", Enum: methodEnum},
+			"params":  {Type: "array", Items: &Schema{}},
+		},
+		Required: []string{"jsonrpc", "method", "id"},
+	}
+	d.Paths[path] = &PathItem{
+		"post": {
+			Summary: "json rpc service, see 'x-jsonrpc-methods' for params and result of methods",
+			RequestBody: &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: request}},
+			},
+			Responses: map[string]*Response{"200": {Description: "json rpc response"}},
+		},
+	}
+}
+
+func getRPCMethodNames(pathItem *PathItem) (methodNames []string) {
+	if pathItem == nil {
+		return nil
+	}
+	op := (*pathItem)["post"]
+	if op == nil || op.RequestBody == nil {
+		return nil
+	}
+	media := op.RequestBody.Content["application/json"]
+	if media == nil || media.Schema == nil || media.Schema.Properties["method"] == nil {
+		return nil
+	}
+	for _, name := range media.Schema.Properties["method"].Enum {
+		if methodName, ok := name.(string); ok {
+			methodNames = append(methodNames, methodName)
+		}
+	}
+	return methodNames
+}
+
+// GetRPCMethods get json rpc methods of service receiver
+func GetRPCMethods(receiver interface{}) (methods []reflect.Method) {
+	rtype := reflect.TypeOf(receiver)
+	for i := 0; i < rtype.NumMethod(); i++ {
+		method := rtype.Method(i)
+		mtype := method.Type
+		if mtype.NumIn() != 4 || mtype.NumOut() != 1 ||
+			mtype.In(1) != httpRequestType ||
+			mtype.In(2).Kind() != reflect.Ptr ||
+			mtype.In(3).Kind() != reflect.Ptr ||
+			mtype.Out(0) != errorType {
+			continue
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
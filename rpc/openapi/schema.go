@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"path"
+	"reflect"
+	"strings"
+)
+
+var (
+	bigIntType        = reflect.TypeOf(big.Int{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Schema json schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+}
+
+// SchemaOf get schema of type, named struct types are put into components
+func (d *Document) SchemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == bigIntType:
+		return &Schema{Type: "integer"}
+	case reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	case reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return &Schema{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.SchemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.SchemaOf(t.Elem())}
+	case reflect.Struct:
+		return d.structSchemaOf(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func getSchemaName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+func (d *Document) structSchemaOf(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return d.buildStructSchema(t)
+	}
+	name := getSchemaName(t)
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, exist := d.Components.Schemas[name]; exist {
+		return ref
+	}
+	d.Components.Schemas[name] = &Schema{} // placeholder for recursive types
+	d.Components.Schemas[name] = d.buildStructSchema(t)
+	return ref
+}
+
+func (d *Document) buildStructSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	d.addStructFields(schema, t)
+	return schema
+}
+
+func (d *Document) addStructFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		if field.Anonymous && !hasTag {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				d.addStructFields(schema, fieldType) // embedded fields are flattened
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		if hasTag {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		schema.Properties[name] = d.SchemaOf(field.Type)
+	}
+}
@@ -0,0 +1,59 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
+)
+
+func getOffsetAndLimit(r *http.Request) (offset, limit int, err error) {
+	vals := r.URL.Query()
+	if offsetStr := vals.Get("offset"); offsetStr != "" {
+		offset, err = common.GetIntFromStr(offsetStr)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if limitStr := vals.Get("limit"); limitStr != "" {
+		limit, err = common.GetIntFromStr(limitStr)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return offset, limit, nil
+}
+
+// AdminPendingSwapsHandler handler
+func AdminPendingSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	_, limit, err := getOffsetAndLimit(r)
+	if err != nil {
+		writeResponse(w, nil, err)
+		return
+	}
+	res, err := swapapi.GetPendingSwaps(limit)
+	writeResponse(w, res, err)
+}
+
+// AdminDcrmAccountsHandler handler
+func AdminDcrmAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetDcrmAccounts()
+	writeResponse(w, res, err)
+}
+
+// AdminBlacklistHandler handler
+func AdminBlacklistHandler(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := getOffsetAndLimit(r)
+	if err != nil {
+		writeResponse(w, nil, err)
+		return
+	}
+	res, err := swapapi.GetBlacklist(offset, limit)
+	writeResponse(w, res, err)
+}
+
+// AdminOraclesStatusHandler handler
+func AdminOraclesStatusHandler(w http.ResponseWriter, r *http.Request) {
+	res := swapapi.GetOraclesStatus()
+	writeResponse(w, res, nil)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
@@ -95,6 +97,12 @@ func NonceInfoHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// GatewayHealthHandler handler
+func GatewayHealthHandler(w http.ResponseWriter, r *http.Request) {
+	res := swapapi.GetGatewayHealth()
+	writeResponse(w, res, nil)
+}
+
 func getBindParam(r *http.Request) string {
 	vals := r.URL.Query()
 	bindVals, exist := vals["bind"]
@@ -227,6 +235,68 @@ func SwapoutHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getSearchSwapsArgs(r *http.Request) (args *swapapi.SearchSwapsArgs, err error) {
+	vals := r.URL.Query()
+	args = &swapapi.SearchSwapsArgs{
+		Direction: vals.Get("direction"),
+		From:      vals.Get("from"),
+		Bind:      vals.Get("bind"),
+		TxID:      vals.Get("txid"),
+		SwapTx:    vals.Get("swaptx"),
+		Status:    vals.Get("status"),
+		MinValue:  vals.Get("minvalue"),
+		MaxValue:  vals.Get("maxvalue"),
+		Cursor:    vals.Get("cursor"),
+	}
+	if pairIDs := vals.Get("pairid"); pairIDs != "" {
+		args.PairIDs = strings.Split(pairIDs, ",")
+	}
+	if startTime := vals.Get("starttime"); startTime != "" {
+		args.StartTime, err = strconv.ParseInt(startTime, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if endTime := vals.Get("endtime"); endTime != "" {
+		args.EndTime, err = strconv.ParseInt(endTime, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if limit := vals.Get("limit"); limit != "" {
+		args.Limit, err = common.GetIntFromStr(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ascending := vals.Get("ascending"); ascending != "" {
+		args.Ascending, err = strconv.ParseBool(ascending)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// SearchSwapsHandler handler
+func SearchSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := getSearchSwapsArgs(r)
+	if err != nil {
+		writeResponse(w, nil, err)
+	} else {
+		res, err := swapapi.SearchSwaps(args)
+		writeResponse(w, res, err)
+	}
+}
+
+// GetSwapBySwapTxHandler handler
+func GetSwapBySwapTxHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swapTx := vars["swaptx"]
+	res, err := swapapi.GetSwapBySwapTx(swapTx)
+	writeResponse(w, res, err)
+}
+
 // PostSwapinHandler handler
 func PostSwapinHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -279,6 +349,34 @@ func GetP2shAddressInfo(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// RegisterDestinationTag handler
+func RegisterDestinationTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+	res, err := swapapi.RegisterDestinationTag(address)
+	writeResponse(w, res, err)
+}
+
+// GetDestinationTagInfo handler
+func GetDestinationTagInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tag, err := strconv.ParseUint(vars["tag"], 10, 32)
+	if err != nil {
+		writeResponse(w, nil, err)
+		return
+	}
+	res, err := swapapi.GetDestinationTagInfo(uint32(tag))
+	writeResponse(w, res, err)
+}
+
+// GetBindDestinationTag handler
+func GetBindDestinationTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+	res, err := swapapi.GetBindDestinationTag(address)
+	writeResponse(w, res, err)
+}
+
 // RegisterAddress handler
 func RegisterAddress(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -1,20 +1,27 @@
 package rpcapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Bridge/admin"
 	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/apikey"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
 	"github.com/anyswap/CrossChain-Bridge/worker"
 )
 
 const (
+	maxImportBlacklistEntries = 2000
+
 	successReuslt = "Success"
 	swapinOp      = "swapin"
 	swapoutOp     = "swapout"
@@ -38,26 +45,60 @@ func (s *RPCAPI) AdminCall(r *http.Request, rawTx, result *string) (err error) {
 		return err
 	}
 	senderAddress := sender.String()
-	if !params.IsAdmin(senderAddress) {
-		switch args.Method {
-		case "blacklist", "maintain", "reswap", "manual", "setnonce", "addpair":
-			return fmt.Errorf("sender %v is not admin", senderAddress)
-		case "bigvalue", "reverify", "replaceswap":
-			if !params.IsAssistant(senderAddress) {
-				return fmt.Errorf("sender %v is not assistant", senderAddress)
-			}
-		default:
-			return fmt.Errorf("unknown admin method '%v'", args.Method)
-		}
+	err = checkPermission(senderAddress, args)
+	if err != nil {
+		return err
 	}
 	log.Info("admin call", "caller", senderAddress, "args", args, "result", result)
-	return doCall(args, result)
+	if args.Method == "publishconfig" {
+		err = publishconfig(args, *rawTx, result)
+	} else {
+		err = doCall(senderAddress, args, result)
+	}
+	addAdminAudit(tx.Hash().String(), senderAddress, args, *result, err)
+	return err
+}
+
+func checkPermission(sender string, args *admin.CallArgs) error {
+	if params.IsAdmin(sender) {
+		return nil
+	}
+	method := args.Method
+	if method == "batch" && len(args.Params) > 0 {
+		method = args.Params[0] // same permission as the batched method
+	}
+	switch method {
+	case "blacklist", "maintain", "reswap", "manual", "setnonce", "addpair", "updatepair", "retirepair", "trustset", "publishconfig", "apikey":
+		return fmt.Errorf("sender %v is not admin", sender)
+	case "bigvalue", "reverify", "replaceswap":
+		if !params.IsAssistant(sender) {
+			return fmt.Errorf("sender %v is not assistant", sender)
+		}
+	default:
+		return fmt.Errorf("unknown admin method '%v'", method)
+	}
+	return nil
+}
+
+// addAdminAudit record one audit entry per admin call (including batch call)
+func addAdminAudit(txHash, caller string, args *admin.CallArgs, result string, err error) {
+	audit := &mongodb.MgoAdminAudit{
+		Key:    txHash,
+		Caller: caller,
+		Method: args.Method,
+		Params: args.Params,
+		Result: result,
+	}
+	if err != nil {
+		audit.Error = err.Error()
+	}
+	_ = mongodb.AddAdminAudit(audit)
 }
 
-func doCall(args *admin.CallArgs, result *string) error {
+func doCall(caller string, args *admin.CallArgs, result *string) error {
 	switch args.Method {
 	case "blacklist":
-		return blacklist(args, result)
+		return blacklist(caller, args, result)
 	case "bigvalue":
 		return bigvalue(args, result)
 	case "maintain":
@@ -74,42 +115,183 @@ func doCall(args *admin.CallArgs, result *string) error {
 		return setnonce(args, result)
 	case "addpair":
 		return addpair(args, result)
+	case "updatepair":
+		return updatepair(args, result)
+	case "retirepair":
+		return retirepair(args, result)
+	case "trustset":
+		return trustset(args, result)
+	case "apikey":
+		return apiKey(args, result)
+	case "batch":
+		return batch(caller, args, result)
 	default:
 		return fmt.Errorf("unknown admin method '%v'", args.Method)
 	}
 }
 
-func blacklist(args *admin.CallArgs, result *string) (err error) {
+func blacklist(caller string, args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) == 0 {
+		return fmt.Errorf("empty params")
+	}
+	operation := args.Params[0]
+	switch operation {
+	case "add":
+		return addToBlacklist(caller, args.Params[1:], result)
+	case "import":
+		return importBlacklist(caller, args.Params[1:], result)
+	case "remove", "query":
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
 	if len(args.Params) != 3 {
 		return fmt.Errorf("wrong number of params, have %v want 3", len(args.Params))
 	}
-	operation := args.Params[0]
 	address := args.Params[1]
 	pairID := args.Params[2]
-	isBlacked := false
-	isQuery := false
-	switch operation {
-	case "add":
-		err = mongodb.AddToBlacklist(address, pairID)
-	case "remove":
+	if operation == "remove" {
 		err = mongodb.RemoveFromBlacklist(address, pairID)
-	case "query":
-		isQuery = true
-		isBlacked, err = mongodb.QueryBlacklist(address, pairID)
+		if err != nil {
+			return err
+		}
+		*result = successReuslt
+		return nil
+	}
+	entry, err := mongodb.FindBlacklistEntry(address, pairID)
+	switch {
+	case err == nil:
+		*result = fmt.Sprintf("is in blacklist (pairID: %v, reason: %v, source: %v, expireAt: %v)",
+			entry.PairID, entry.Reason, entry.Source, entry.ExpireAt)
+	case errors.Is(err, mongodb.ErrItemNotFound):
+		*result = "is not in blacklist"
 	default:
-		return fmt.Errorf("unknown operation '%v'", operation)
+		return err
 	}
+	return nil
+}
+
+// params are [address, pairID, reason, source, expireAt], the last three are optional
+func addToBlacklist(caller string, params []string, result *string) (err error) {
+	if len(params) < 2 || len(params) > 5 {
+		return fmt.Errorf("wrong number of params, have %v want 3 to 6", len(params)+1)
+	}
+	entry := &admin.BlacklistEntry{
+		Address: params[0],
+		PairID:  params[1],
+	}
+	if len(params) > 2 {
+		entry.Reason = params[2]
+	}
+	if len(params) > 3 {
+		entry.Source = params[3]
+	}
+	if len(params) > 4 && params[4] != "" {
+		entry.ExpireAt, err = strconv.ParseInt(params[4], 10, 64)
+		if err != nil {
+			return fmt.Errorf("wrong expire time '%v': %w", params[4], err)
+		}
+	}
+	mb, err := convertBlacklistEntry(caller, entry)
 	if err != nil {
 		return err
 	}
-	if isQuery {
-		if isBlacked {
-			*result = "is in blacklist"
-		} else {
-			*result = "is not in blacklist"
+	err = mongodb.AddToBlacklist(mb)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+// params are [entries in json]
+func importBlacklist(caller string, params []string, result *string) error {
+	if len(params) != 1 {
+		return fmt.Errorf("wrong number of params, have %v want 2", len(params)+1)
+	}
+	entries, err := admin.DecodeBlacklistEntries(params[0])
+	if err != nil {
+		return fmt.Errorf("wrong blacklist entries: %w", err)
+	}
+	switch {
+	case len(entries) == 0:
+		return fmt.Errorf("empty blacklist entries")
+	case len(entries) > maxImportBlacklistEntries:
+		return fmt.Errorf("too many blacklist entries, have %v max %v", len(entries), maxImportBlacklistEntries)
+	}
+	mbs := make([]*mongodb.MgoBlackAccount, len(entries))
+	for i, entry := range entries {
+		mbs[i], err = convertBlacklistEntry(caller, entry)
+		if err != nil {
+			return fmt.Errorf("blacklist entry %v: %w", i, err)
+		}
+	}
+	inserted, replaced, err := mongodb.ImportBlacklist(mbs)
+	if err != nil {
+		return err
+	}
+	*result = fmt.Sprintf("imported %v entries, inserted %v, replaced %v", len(mbs), inserted, replaced)
+	return nil
+}
+
+func convertBlacklistEntry(caller string, entry *admin.BlacklistEntry) (*mongodb.MgoBlackAccount, error) {
+	if entry == nil || entry.Address == "" {
+		return nil, fmt.Errorf("empty address")
+	}
+	if entry.PairID == "" {
+		return nil, fmt.Errorf("empty pairID")
+	}
+	if entry.ExpireAt < 0 {
+		return nil, fmt.Errorf("negative expire time %v", entry.ExpireAt)
+	}
+	return &mongodb.MgoBlackAccount{
+		Address:   entry.Address,
+		PairID:    entry.PairID,
+		Reason:    entry.Reason,
+		Source:    entry.Source,
+		CreatedBy: caller,
+		ExpireAt:  entry.ExpireAt,
+	}, nil
+}
+
+func apiKey(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) == 0 {
+		return fmt.Errorf("empty params")
+	}
+	operation := args.Params[0]
+	switch operation {
+	case "issue":
+		if len(args.Params) != 4 && len(args.Params) != 5 {
+			return fmt.Errorf("wrong number of params, have %v want 4 or 5", len(args.Params))
+		}
+		name := args.Params[1]
+		rateLimit, err := strconv.ParseFloat(args.Params[2], 64)
+		if err != nil {
+			return fmt.Errorf("wrong rate limit: %w", err)
+		}
+		dailyQuota, err := common.GetUint64FromStr(args.Params[3])
+		if err != nil {
+			return fmt.Errorf("wrong daily quota: %w", err)
+		}
+		var scopes []string
+		if len(args.Params) == 5 && args.Params[4] != "" {
+			scopes = strings.Split(args.Params[4], ",")
+		}
+		key, keyID, err := apikey.Issue(name, rateLimit, dailyQuota, scopes...)
+		if err != nil {
+			return err
+		}
+		*result = fmt.Sprintf("key: %v, keyID: %v", key, keyID)
+	case "revoke":
+		if len(args.Params) != 2 {
+			return fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
+		}
+		err = apikey.Revoke(args.Params[1])
+		if err != nil {
+			return err
 		}
-	} else {
 		*result = successReuslt
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
 	}
 	return nil
 }
@@ -359,10 +541,61 @@ func setnonce(args *admin.CallArgs, result *string) (err error) {
 	return nil
 }
 
-func addpair(args *admin.CallArgs, result *string) (err error) {
+func updatepair(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 1 {
+		return fmt.Errorf("wrong number of params, have %v want 1", len(args.Params))
+	}
+	configFile := args.Params[0]
+	pairConfig, err := tokens.UpdatePairConfig(configFile)
+	if err != nil {
+		return err
+	}
+	worker.AddSwapJob(pairConfig)
+	*result = successReuslt
+	if pending := tokens.GetPendingPairConfig(pairConfig.PairID); pending != nil {
+		*result += fmt.Sprintf(", unsafe changes wait for activation (height %v, time %v)", pending.ActivateHeight, pending.ActivateTime)
+	}
+	return nil
+}
+
+func retirepair(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 1 {
+		return fmt.Errorf("wrong number of params, have %v want 1", len(args.Params))
+	}
+	pairID := args.Params[0]
+	_, err = tokens.RetirePairConfig(pairID)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+// the signed admin tx is published as the signature of config bundle
+func publishconfig(args *admin.CallArgs, rawTx string, result *string) (err error) {
 	if len(args.Params) != 1 {
 		return fmt.Errorf("wrong number of params, have %v want 1", len(args.Params))
 	}
+	bundle := params.BuildConfigBundle()
+	bundleHash, err := bundle.Hash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(args.Params[0], bundleHash.String()) {
+		return fmt.Errorf("config bundle hash mismatch, signed %v, current %v", args.Params[0], bundleHash.String())
+	}
+	err = swapapi.PublishConfigBundle(bundle, rawTx)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func addpair(args *admin.CallArgs, result *string) (err error) {
+	if !(len(args.Params) == 1 || len(args.Params) == 2) {
+		return fmt.Errorf("wrong number of params, have %v want 1 or 2", len(args.Params))
+	}
 	configFile := args.Params[0]
 	pairConfig, err := tokens.AddPairConfig(configFile)
 	if err != nil {
@@ -370,5 +603,37 @@ func addpair(args *admin.CallArgs, result *string) (err error) {
 	}
 	worker.AddSwapJob(pairConfig)
 	*result = successReuslt
+
+	// create trustline of ripple non native pair if trust limit is specified
+	if len(args.Params) > 1 && args.Params[1] != "" {
+		txHash, errt := createTrustline(pairConfig.PairID, args.Params[1])
+		if errt != nil {
+			*result += ", create trustline failed: " + errt.Error()
+		} else {
+			*result += ", trust set txHash is " + txHash
+		}
+	}
 	return nil
 }
+
+func trustset(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 2 {
+		return fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
+	}
+	pairID := args.Params[0]
+	trustLimit := args.Params[1]
+	txHash, err := createTrustline(pairID, trustLimit)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt + " txHash is " + txHash
+	return nil
+}
+
+func createTrustline(pairID, trustLimit string) (txHash string, err error) {
+	rippleBridge, ok := tokens.SrcBridge.(*ripple.Bridge)
+	if !ok {
+		return "", tokens.ErrNoRippleBridge
+	}
+	return rippleBridge.CreateTrustline(pairID, trustLimit)
+}
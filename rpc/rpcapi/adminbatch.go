@@ -0,0 +1,262 @@
+package rpcapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	maxBatchItems          = 1000
+	defaultBatchQueryLimit = 100
+)
+
+// methods which can be called in batch, value is the number of params of each item
+var batchMethods = map[string][]int{
+	"bigvalue":  {3},    // txid pairID bind
+	"reverify":  {3},    // txid pairID bind
+	"reswap":    {3},    // txid pairID bind
+	"manual":    {3, 4}, // txid pairID bind [memo]
+	"blacklist": {2},    // address pairID
+}
+
+func batch(caller string, args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 4 {
+		return fmt.Errorf("wrong number of params, have %v want 4", len(args.Params))
+	}
+	method := args.Params[0]
+	operation := args.Params[1]
+	mode := args.Params[2]
+	if _, exist := batchMethods[method]; !exist {
+		return fmt.Errorf("method '%v' can not be called in batch", method)
+	}
+	var isDryRun bool
+	switch mode {
+	case admin.BatchModeRun:
+	case admin.BatchModeDryRun:
+		isDryRun = true
+	default:
+		return fmt.Errorf("unknown batch mode '%v'", mode)
+	}
+	spec, err := admin.DecodeBatchSpec(args.Params[3])
+	if err != nil {
+		return fmt.Errorf("wrong batch spec: %w", err)
+	}
+	items, err := getBatchItems(method, operation, spec)
+	if err != nil {
+		return err
+	}
+
+	batchResult := &admin.BatchResult{
+		Method:    method,
+		Operation: operation,
+		DryRun:    isDryRun,
+		Total:     len(items),
+		Items:     make([]*admin.BatchItemResult, 0, len(items)),
+	}
+	for _, item := range items {
+		itemArgs := &admin.CallArgs{
+			Method:    method,
+			Params:    append([]string{operation}, item...),
+			Timestamp: args.Timestamp,
+		}
+		itemResult := &admin.BatchItemResult{Params: item}
+		var res string
+		if isDryRun {
+			err = previewBatchItem(itemArgs, &res)
+		} else {
+			err = doCall(caller, itemArgs, &res)
+		}
+		if err != nil {
+			itemResult.Error = err.Error()
+			batchResult.Failed++
+		} else {
+			itemResult.Result = res
+			batchResult.Success++
+		}
+		batchResult.Items = append(batchResult.Items, itemResult)
+	}
+
+	data, err := json.Marshal(batchResult)
+	if err != nil {
+		return err
+	}
+	*result = string(data)
+	return nil
+}
+
+func getBatchItems(method, operation string, spec *admin.BatchSpec) ([][]string, error) {
+	switch {
+	case spec.Query != nil && len(spec.Items) != 0:
+		return nil, fmt.Errorf("batch items and query are exclusive")
+	case spec.Query != nil:
+		return queryBatchItems(method, operation, spec.Query)
+	case len(spec.Items) == 0:
+		return nil, fmt.Errorf("empty batch items")
+	case len(spec.Items) > maxBatchItems:
+		return nil, fmt.Errorf("too many batch items, have %v max %v", len(spec.Items), maxBatchItems)
+	}
+	for i, item := range spec.Items {
+		if !isValidBatchItemLength(method, len(item)) {
+			return nil, fmt.Errorf("wrong number of params of batch item %v, have %v want %v", i, len(item), batchMethods[method])
+		}
+	}
+	return spec.Items, nil
+}
+
+func isValidBatchItemLength(method string, length int) bool {
+	for _, want := range batchMethods[method] {
+		if length == want {
+			return true
+		}
+	}
+	return false
+}
+
+// query swaps with status (and value range) of swap results,
+// or of swaps for reverify as verify failed swaps have no swap result.
+func queryBatchItems(method, operation string, query *admin.BatchQuery) ([][]string, error) {
+	var isSwapin bool
+	switch operation {
+	case swapinOp, passSwapinOp, failSwapinOp:
+		isSwapin = true
+	case swapoutOp, passSwapoutOp, failSwapoutOp:
+		isSwapin = false
+	default:
+		return nil, fmt.Errorf("can not query swaps of operation '%v'", operation)
+	}
+	statuses := mongodb.GetStatusesFromStr(query.Status)
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("batch query must specify status")
+	}
+	limit := query.Limit
+	switch {
+	case limit <= 0:
+		limit = defaultBatchQueryLimit
+	case limit > maxBatchItems:
+		limit = maxBatchItems
+	}
+
+	var items [][]string
+	addItem := func(txid, pairID, bind string) {
+		item := []string{txid, pairID, bind}
+		if method == "manual" && query.Memo != "" {
+			item = append(item, query.Memo)
+		}
+		items = append(items, item)
+	}
+
+	if method == "reverify" {
+		if query.MinValue != "" || query.MaxValue != "" {
+			return nil, fmt.Errorf("value range is not supported in reverify query")
+		}
+		swaps, err := mongodb.FindSwapsWithStatuses(isSwapin, query.PairID, statuses, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, swap := range swaps {
+			addItem(swap.TxID, swap.PairID, swap.Bind)
+		}
+		return items, nil
+	}
+
+	filter := &mongodb.SwapSearchFilter{
+		Status:    query.Status,
+		MinValue:  query.MinValue,
+		MaxValue:  query.MaxValue,
+		Ascending: true,
+		Limit:     limit,
+	}
+	if query.PairID != "" {
+		filter.PairIDs = []string{query.PairID}
+	}
+	results, err := mongodb.SearchSwapResults(isSwapin, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		addItem(res.TxID, res.PairID, res.Bind)
+	}
+	return items, nil
+}
+
+// previewBatchItem show current state of the item and run the same checks
+// as the real call without modification
+func previewBatchItem(args *admin.CallArgs, result *string) error {
+	operation := args.Params[0]
+	if args.Method == "blacklist" {
+		switch operation {
+		case "add", "remove", "query":
+		default:
+			return fmt.Errorf("unknown operation '%v'", operation)
+		}
+		isBlacked, err := mongodb.QueryBlacklist(args.Params[1], args.Params[2])
+		if err != nil {
+			return err
+		}
+		*result = fmt.Sprintf("is in blacklist: %v", isBlacked)
+		return nil
+	}
+
+	var isSwapin bool
+	switch operation {
+	case swapinOp, passSwapinOp, failSwapinOp:
+		isSwapin = true
+	case swapoutOp, passSwapoutOp, failSwapoutOp:
+		isSwapin = false
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	txid, pairID, bind := args.Params[1], args.Params[2], args.Params[3]
+	swap, err := mongodb.FindSwap(isSwapin, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	status := fmt.Sprintf("swap status: %v", swap.Status.String())
+	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
+	if err == nil {
+		status += fmt.Sprintf(", result status: %v, value: %v", res.Status.String(), res.Value)
+	}
+	err = checkBatchItem(args.Method, operation, txid, pairID, bind, isSwapin)
+	if err != nil {
+		return fmt.Errorf("%v, %w", status, err)
+	}
+	*result = status
+	return nil
+}
+
+// checkBatchItem check the preconditions of the real call of batch item
+func checkBatchItem(method, operation, txid, pairID, bind string, isSwapin bool) error {
+	switch method {
+	case "bigvalue":
+		if operation != passSwapinOp && operation != passSwapoutOp {
+			return fmt.Errorf("unknown operation '%v'", operation)
+		}
+		return mongodb.CheckPassBigValue(txid, pairID, bind, isSwapin)
+	case "reverify":
+		if operation != swapinOp && operation != swapoutOp {
+			return fmt.Errorf("unknown operation '%v'", operation)
+		}
+		_, err := tokens.GetCrossChainBridge(isSwapin).VerifyTransaction(pairID, txid, true)
+		if err != nil {
+			return err
+		}
+		return mongodb.CheckReverifySwap(txid, pairID, bind, isSwapin)
+	case "reswap":
+		if operation != swapinOp && operation != swapoutOp {
+			return fmt.Errorf("unknown operation '%v'", operation)
+		}
+		return mongodb.CheckReswap(txid, pairID, bind, isSwapin)
+	case "manual":
+		isPass := operation == passSwapinOp || operation == passSwapoutOp
+		if !isPass && operation != failSwapinOp && operation != failSwapoutOp {
+			return fmt.Errorf("unknown operation '%v'", operation)
+		}
+		return mongodb.CheckManualManageSwap(txid, pairID, bind, isSwapin, isPass)
+	default:
+		return fmt.Errorf("method '%v' can not be called in batch", method)
+	}
+}
@@ -0,0 +1,62 @@
+package rpcapi
+
+import (
+	"net/http"
+
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
+	"github.com/anyswap/CrossChain-Bridge/rpc/apikey"
+)
+
+// AdminReadAPI admin read api handler, requires api key with adminread scope
+type AdminReadAPI struct{}
+
+// RPCAdminListArgs admin list args
+type RPCAdminListArgs struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// GetPendingSwaps api
+func (s *AdminReadAPI) GetPendingSwaps(r *http.Request, limit *int, result *[]*swapapi.PendingSwap) error {
+	if err := apikey.CheckAdminRead(r.Context()); err != nil {
+		return err
+	}
+	res, err := swapapi.GetPendingSwaps(*limit)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetDcrmAccounts api
+func (s *AdminReadAPI) GetDcrmAccounts(r *http.Request, args *RPCNullArgs, result *[]*swapapi.PairDcrmAccounts) error {
+	if err := apikey.CheckAdminRead(r.Context()); err != nil {
+		return err
+	}
+	res, err := swapapi.GetDcrmAccounts()
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetBlacklist api
+func (s *AdminReadAPI) GetBlacklist(r *http.Request, args *RPCAdminListArgs, result *[]*swapapi.BlackAccount) error {
+	if err := apikey.CheckAdminRead(r.Context()); err != nil {
+		return err
+	}
+	res, err := swapapi.GetBlacklist(args.Offset, args.Limit)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetOraclesStatus api
+func (s *AdminReadAPI) GetOraclesStatus(r *http.Request, args *RPCNullArgs, result *[]*swapapi.OracleStatus) error {
+	if err := apikey.CheckAdminRead(r.Context()); err != nil {
+		return err
+	}
+	*result = swapapi.GetOraclesStatus()
+	return nil
+}
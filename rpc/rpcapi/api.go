@@ -9,6 +9,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
 // RPCAPI rpc api handler
@@ -83,6 +84,24 @@ func (s *RPCAPI) GetTokenPairsInfo(r *http.Request, pairIDs *string, result *map
 	return nil
 }
 
+// GetCurrentConfigBundle api
+func (s *RPCAPI) GetCurrentConfigBundle(r *http.Request, args *RPCNullArgs, result *params.ConfigBundle) error {
+	res, err := swapapi.GetCurrentConfigBundle()
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetConfigBundle api
+func (s *RPCAPI) GetConfigBundle(r *http.Request, args *RPCNullArgs, result *params.SignedConfigBundle) error {
+	res, err := swapapi.GetConfigBundle()
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // GetNonceInfo api
 func (s *RPCAPI) GetNonceInfo(r *http.Request, args *RPCNullArgs, result *swapapi.SwapNonceInfo) error {
 	res, err := swapapi.GetNonceInfo()
@@ -92,6 +111,12 @@ func (s *RPCAPI) GetNonceInfo(r *http.Request, args *RPCNullArgs, result *swapap
 	return err
 }
 
+// GetGatewayHealth api
+func (s *RPCAPI) GetGatewayHealth(r *http.Request, args *RPCNullArgs, result *map[string][]*tools.EndpointHealth) error {
+	*result = swapapi.GetGatewayHealth()
+	return nil
+}
+
 // RPCTxAndPairIDArgs txid and pairID
 type RPCTxAndPairIDArgs struct {
 	TxID   string `json:"txid"`
@@ -217,6 +242,24 @@ func (s *RPCAPI) GetSwapoutHistory(r *http.Request, args *RPCQueryHistoryArgs, r
 	return err
 }
 
+// SearchSwaps api
+func (s *RPCAPI) SearchSwaps(r *http.Request, args *swapapi.SearchSwapsArgs, result *swapapi.SearchSwapsResult) error {
+	res, err := swapapi.SearchSwaps(args)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetSwapBySwapTx api
+func (s *RPCAPI) GetSwapBySwapTx(r *http.Request, swapTx *string, result *swapapi.SwapBySwapTxResult) error {
+	res, err := swapapi.GetSwapBySwapTx(*swapTx)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // Swapin api
 func (s *RPCAPI) Swapin(r *http.Request, args *RPCTxAndPairIDArgs, result *swapapi.PostResult) error {
 	txid, pairID, _, err := args.getTxAndPairID()
@@ -302,6 +345,33 @@ func (s *RPCAPI) GetP2shAddressInfo(r *http.Request, p2shAddress *string, result
 	return err
 }
 
+// RegisterDestinationTag api
+func (s *RPCAPI) RegisterDestinationTag(r *http.Request, bindAddress *string, result *tokens.DestinationTagInfo) error {
+	res, err := swapapi.RegisterDestinationTag(*bindAddress)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetDestinationTagInfo api
+func (s *RPCAPI) GetDestinationTagInfo(r *http.Request, tag *uint32, result *tokens.DestinationTagInfo) error {
+	res, err := swapapi.GetDestinationTagInfo(*tag)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetBindDestinationTag api
+func (s *RPCAPI) GetBindDestinationTag(r *http.Request, bindAddress *string, result *tokens.DestinationTagInfo) error {
+	res, err := swapapi.GetBindDestinationTag(*bindAddress)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // GetLatestScanInfo api
 func (s *RPCAPI) GetLatestScanInfo(r *http.Request, isSrc *bool, result *swapapi.LatestScanInfo) error {
 	res, err := swapapi.GetLatestScanInfo(*isSrc)
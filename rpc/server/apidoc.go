@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/openapi"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+const (
+	rpcPath     = "/rpc"
+	openapiPath = "/openapi.json"
+)
+
+var (
+	swapQuery    = []string{"bind"}
+	historyQuery = []string{"offset", "limit", "status"}
+	searchQuery  = []string{"direction", "pairid", "from", "bind", "txid", "swaptx", "status",
+		"starttime", "endtime", "minvalue", "maxvalue", "cursor", "limit", "ascending"}
+
+	// key is path template registered in router, every route must have a doc
+	restRouteDocs = map[string]*openapi.RouteDoc{
+		"/serverinfo":    {Summary: "get server info", Result: swapapi.ServerInfo{}},
+		"/versioninfo":   {Summary: "get version info", Result: ""},
+		"/oracleinfo":    {Summary: "get oracles heartbeat", Result: map[string]string{}},
+		"/nonceinfo":     {Summary: "get swap nonce info", Result: swapapi.SwapNonceInfo{}},
+		"/gatewayhealth": {Summary: "get gateway health", Result: map[string][]*tools.EndpointHealth{}},
+		"/statusinfo":    {Summary: "get swap status statistics", Query: []string{"status"}, Result: map[string]map[string]interface{}{}},
+
+		"/pairinfo/{pairid}":   {Summary: "get token pair config", Result: tokens.TokenPairConfig{}},
+		"/pairsinfo/{pairids}": {Summary: "get token pairs config, pairids is comma separated", Result: map[string]*tokens.TokenPairConfig{}},
+
+		"/swapin/post/{pairid}/{txid}":  {Summary: "post swapin", Result: swapapi.PostResult("")},
+		"/swapout/post/{pairid}/{txid}": {Summary: "post swapout", Result: swapapi.PostResult("")},
+		"/swapin/p2sh/{txid}/{bind}":    {Summary: "post p2sh swapin (btc)", Result: swapapi.PostResult("")},
+		"/swapin/retry/{pairid}/{txid}": {Summary: "retry swapin (eth like)", Result: swapapi.PostResult("")},
+
+		"/swapin/{pairid}/{txid}":             {Summary: "get swapin", Query: swapQuery, Result: swapapi.SwapInfo{}},
+		"/swapout/{pairid}/{txid}":            {Summary: "get swapout", Query: swapQuery, Result: swapapi.SwapInfo{}},
+		"/swapin/{pairid}/{txid}/raw":         {Summary: "get raw swapin", Query: swapQuery, Result: swapapi.Swap{}},
+		"/swapout/{pairid}/{txid}/raw":        {Summary: "get raw swapout", Query: swapQuery, Result: swapapi.Swap{}},
+		"/swapin/{pairid}/{txid}/rawresult":   {Summary: "get raw swapin result", Query: swapQuery, Result: swapapi.SwapResult{}},
+		"/swapout/{pairid}/{txid}/rawresult":  {Summary: "get raw swapout result", Query: swapQuery, Result: swapapi.SwapResult{}},
+		"/swapin/history/{pairid}/{address}":  {Summary: "get swapin history", Query: historyQuery, Result: []*swapapi.SwapInfo{}},
+		"/swapout/history/{pairid}/{address}": {Summary: "get swapout history", Query: historyQuery, Result: []*swapapi.SwapInfo{}},
+		"/swap/search":                        {Summary: "search swaps with cursor pagination", Query: searchQuery, Result: swapapi.SearchSwapsResult{}},
+		"/swap/swaptx/{swaptx}":               {Summary: "get swaps by swap tx", Result: swapapi.SwapBySwapTxResult{}},
+		"/p2sh/{address}":                     {Summary: "get p2sh address info (btc)", Result: tokens.P2shAddressInfo{}},
+		"/p2sh/bind/{address}":                {Summary: "register p2sh address (btc)", Result: tokens.P2shAddressInfo{}},
+		"/destinationtag/{tag}":               {Summary: "get destination tag info (ripple)", Result: tokens.DestinationTagInfo{}},
+		"/destinationtag/bind/{address}":      {Summary: "get or register destination tag of bind address (ripple)", Result: tokens.DestinationTagInfo{}},
+		"/registered/{address}":               {Summary: "get registered address", Result: swapapi.RegisteredAddress{}},
+		"/register/{address}":                 {Summary: "register address (eth like)", Result: swapapi.PostResult("")},
+		"/admin/pendingswaps":                 {Summary: "get swaps awaiting admin action (adminread api key)", Query: []string{"limit"}, Result: []*swapapi.PendingSwap{}},
+		"/admin/dcrmaccounts":                 {Summary: "get balances and nonces of dcrm addresses (adminread api key)", Result: []*swapapi.PairDcrmAccounts{}},
+		"/admin/blacklist":                    {Summary: "get blacklist (adminread api key)", Query: []string{"offset", "limit"}, Result: []*swapapi.BlackAccount{}},
+		"/admin/oraclestatus":                 {Summary: "get oracles heartbeat status (adminread api key)", Result: []*swapapi.OracleStatus{}},
+		"/ws":                                 {Summary: "websocket subscription of swap updates"},
+		openapiPath:                           {Summary: "get openapi document"},
+	}
+)
+
+// buildAPIDocument build openapi document of routes registered in router
+func buildAPIDocument(r *mux.Router) (*openapi.Document, error) {
+	doc := openapi.NewDocument("CrossChain-Bridge API", params.VersionWithMeta)
+	err := doc.AddRoutes(r, restRouteDocs)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range rpcServices {
+		doc.AddJSONRPCService(rpcPath, service.name, service.receiver)
+	}
+	return doc, nil
+}
+
+func registerAPIDocument(r *mux.Router) {
+	var docData []byte
+	r.HandleFunc(openapiPath, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(docData)
+	}).Methods("GET")
+
+	doc, err := buildAPIDocument(r)
+	if err != nil {
+		log.Fatal("build api document failed", "err", err)
+	}
+	docData, err = json.Marshal(doc)
+	if err != nil {
+		log.Fatal("marshal api document failed", "err", err)
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/openapi"
+)
+
+func newTestRouter() *mux.Router {
+	params.SetConfig(&params.BridgeConfig{})
+	r := mux.NewRouter()
+	initRouter(r)
+	return r
+}
+
+func TestRouteDocsInSync(t *testing.T) {
+	registered := make(map[string]bool)
+	err := newTestRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		registered[path] = true
+		if path != rpcPath && restRouteDocs[path] == nil {
+			t.Errorf("route %v has no api doc", path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path := range restRouteDocs {
+		if !registered[path] {
+			t.Errorf("api doc of %v has no route", path)
+		}
+	}
+}
+
+func TestAPIDocumentInSync(t *testing.T) {
+	r := newTestRouter()
+	doc, err := buildAPIDocument(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = json.Marshal(doc); err != nil {
+		t.Fatalf("marshal api document failed: %v", err)
+	}
+	err = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			t.Errorf("route %v is not in api document", path)
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil || path == rpcPath {
+			return nil
+		}
+		for _, method := range methods {
+			if (*pathItem)[strings.ToLower(method)] == nil {
+				t.Errorf("method %v of route %v is not in api document", method, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path := range doc.Paths {
+		for _, param := range openapi.GetPathParams(path) {
+			if !strings.Contains(path, "{"+param+"}") {
+				t.Errorf("wrong path param %v of %v", param, path)
+			}
+		}
+	}
+	methodsCount := 0
+	for _, service := range rpcServices {
+		methods := openapi.GetRPCMethods(service.receiver)
+		if len(methods) == 0 {
+			t.Errorf("json rpc service %v has no methods", service.name)
+		}
+		methodsCount += len(methods)
+		for _, method := range methods {
+			if doc.JSONRPC[service.name+"."+method.Name] == nil {
+				t.Errorf("json rpc method %v.%v is not in api document", service.name, method.Name)
+			}
+		}
+	}
+	if methodsCount != len(doc.JSONRPC) {
+		t.Fatalf("json rpc methods count mismatch, services have %v, document has %v", methodsCount, len(doc.JSONRPC))
+	}
+	pathItem := doc.Paths[rpcPath]
+	if pathItem == nil || len(getRPCMethodEnum(pathItem)) != methodsCount {
+		t.Errorf("json rpc method enum of %v mismatch", rpcPath)
+	}
+}
+
+func getRPCMethodEnum(pathItem *openapi.PathItem) []interface{} {
+	op := (*pathItem)["post"]
+	if op == nil || op.RequestBody == nil {
+		return nil
+	}
+	return op.RequestBody.Content["application/json"].Schema.Properties["method"].Enum
+}
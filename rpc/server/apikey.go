@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/didip/tollbooth/v6/limiter"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/apikey"
+)
+
+const maxRPCRequestSize = 1024 * 1024
+
+// json rpc methods restrictable to keyed clients
+var rpcWriteMethods = map[string]bool{
+	"swap.Swapin":                 true,
+	"swap.Swapout":                true,
+	"swap.P2shSwapin":             true,
+	"swap.RetrySwapin":            true,
+	"swap.RegisterAddress":        true,
+	"swap.RegisterP2shAddress":    true,
+	"swap.RegisterDestinationTag": true,
+}
+
+func isAPIKeyRequiredForWrite() bool {
+	return params.GetServerConfig().APIServer.RequireAPIKeyForWrite
+}
+
+// apiKeyHandler limit keyed clients by the rate limit and quota of the key,
+// and limit other clients (including those with invalid keys) by the ip rate limiter
+func apiKeyHandler(ipLimiter *limiter.Limiter, next http.Handler) http.Handler {
+	keyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(apikey.HeaderName)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		keyID, err := apikey.Check(key)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, apikey.ErrInvalidAPIKey), errors.Is(err, apikey.ErrAPIKeyRevoked):
+				status = http.StatusUnauthorized
+			case errors.Is(err, apikey.ErrRateLimitExceeded), errors.Is(err, apikey.ErrQuotaExceeded):
+				status = http.StatusTooManyRequests
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(apikey.WithKeyID(r.Context(), keyID)))
+	})
+	ipLimitedHandler := tollbooth.LimitHandler(ipLimiter, keyHandler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only keys validated recently skip the ip rate limiter,
+		// others are limited before querying database
+		if key := r.Header.Get(apikey.HeaderName); key != "" && apikey.IsCachedValidKey(key) {
+			keyHandler.ServeHTTP(w, r)
+			return
+		}
+		ipLimitedHandler.ServeHTTP(w, r)
+	})
+}
+
+func hasAPIKey(r *http.Request) bool {
+	return apikey.GetKeyIDFromContext(r.Context()) != ""
+}
+
+func writeAPIKeyRequired(w http.ResponseWriter) {
+	http.Error(w, "api key is required", http.StatusUnauthorized)
+}
+
+// writeHandler restrict RESTful write endpoint to keyed clients if configed
+func writeHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if isAPIKeyRequiredForWrite() && !hasAPIKey(r) {
+			writeAPIKeyRequired(w)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// rpcWriteHandler restrict json rpc write methods to keyed clients if configed
+func rpcWriteHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAPIKeyRequiredForWrite() || hasAPIKey(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRPCRequestSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(body, &req) == nil && rpcWriteMethods[req.Method] {
+			writeAPIKeyRequired(w)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminReadHandler restrict admin read endpoint to keys with adminread scope
+func adminReadHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !hasAPIKey(r) {
+			writeAPIKeyRequired(w)
+			return
+		}
+		if err := apikey.CheckAdminRead(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}
+}
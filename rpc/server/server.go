@@ -17,16 +17,34 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/apikey"
 	"github.com/anyswap/CrossChain-Bridge/rpc/restapi"
 	"github.com/anyswap/CrossChain-Bridge/rpc/rpcapi"
+	"github.com/anyswap/CrossChain-Bridge/rpc/wsapi"
 )
 
+type rpcService struct {
+	name     string
+	receiver interface{}
+}
+
+// json rpc services served at rpcPath
+var rpcServices = []*rpcService{
+	{name: "swap", receiver: new(rpcapi.RPCAPI)},
+	{name: "admin", receiver: new(rpcapi.AdminReadAPI)},
+}
+
 // StartAPIServer start api server
 func StartAPIServer() {
 	router := mux.NewRouter()
 	initRouter(router)
 
+	if mongodb.HasClient() {
+		apikey.StartFlushQuotaJob()
+	}
+
 	apiPort := params.GetAPIPort()
 	apiServer := params.GetServerConfig().APIServer
 	allowedOrigins := apiServer.AllowedOrigins
@@ -40,7 +58,7 @@ func StartAPIServer() {
 	}
 	if len(allowedOrigins) != 0 {
 		corsOptions = append(corsOptions,
-			handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type"}),
+			handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", apikey.HeaderName}),
 			handlers.AllowedOrigins(allowedOrigins),
 		)
 	}
@@ -51,7 +69,7 @@ func StartAPIServer() {
 			DefaultExpirationTTL: 600 * time.Second,
 		},
 	)
-	handler := tollbooth.LimitHandler(lmt, handlers.CORS(corsOptions...)(router))
+	handler := apiKeyHandler(lmt, handlers.CORS(corsOptions...)(router))
 	svr := http.Server{
 		Addr:         fmt.Sprintf(":%v", apiPort),
 		ReadTimeout:  60 * time.Second,
@@ -78,6 +96,7 @@ func doCleanup(svr *http.Server) {
 	if err := svr.Shutdown(ctx); err != nil {
 		log.Error("Server Shutdown failed", "err", err)
 	}
+	wsapi.CloseAllConns()
 	log.Info("Close http server success")
 }
 
@@ -90,25 +109,31 @@ func initRouter(r *mux.Router) {
 
 	rpcserver := rpc.NewServer()
 	rpcserver.RegisterCodec(rpcjson.NewCodec(), "application/json")
-	err := rpcserver.RegisterService(new(rpcapi.RPCAPI), "swap")
-	if err != nil {
-		log.Fatal("start rpc service failed", "err", err)
+	for _, service := range rpcServices {
+		err := rpcserver.RegisterService(service.receiver, service.name)
+		if err != nil {
+			log.Fatal("start rpc service failed", "service", service.name, "err", err)
+		}
 	}
 
-	r.Handle("/rpc", rpcserver)
+	r.Handle(rpcPath, rpcWriteHandler(rpcserver))
+
+	wsapi.StartSubscriptionHub()
+	r.HandleFunc("/ws", wsapi.ServeWebSocket).Methods("GET")
 
 	r.HandleFunc("/serverinfo", restapi.ServerInfoHandler).Methods("GET")
 	r.HandleFunc("/versioninfo", restapi.VersionInfoHandler).Methods("GET")
 	r.HandleFunc("/oracleinfo", restapi.OracleInfoHandler).Methods("GET")
 	r.HandleFunc("/nonceinfo", restapi.NonceInfoHandler).Methods("GET")
+	r.HandleFunc("/gatewayhealth", restapi.GatewayHealthHandler).Methods("GET")
 	r.HandleFunc("/statusinfo", restapi.StatusInfoHandler).Methods("GET")
 	r.HandleFunc("/pairinfo/{pairid}", restapi.TokenPairInfoHandler).Methods("GET")
 	r.HandleFunc("/pairsinfo/{pairids}", restapi.TokenPairsInfoHandler).Methods("GET")
 
-	r.HandleFunc("/swapin/post/{pairid}/{txid}", restapi.PostSwapinHandler).Methods("POST")
-	r.HandleFunc("/swapout/post/{pairid}/{txid}", restapi.PostSwapoutHandler).Methods("POST")
-	r.HandleFunc("/swapin/p2sh/{txid}/{bind}", restapi.PostP2shSwapinHandler).Methods("POST")
-	r.HandleFunc("/swapin/retry/{pairid}/{txid}", restapi.RetrySwapinHandler).Methods("POST")
+	r.HandleFunc("/swapin/post/{pairid}/{txid}", writeHandler(restapi.PostSwapinHandler)).Methods("POST")
+	r.HandleFunc("/swapout/post/{pairid}/{txid}", writeHandler(restapi.PostSwapoutHandler)).Methods("POST")
+	r.HandleFunc("/swapin/p2sh/{txid}/{bind}", writeHandler(restapi.PostP2shSwapinHandler)).Methods("POST")
+	r.HandleFunc("/swapin/retry/{pairid}/{txid}", writeHandler(restapi.RetrySwapinHandler)).Methods("POST")
 
 	r.HandleFunc("/swapin/{pairid}/{txid}", restapi.GetSwapinHandler).Methods("GET")
 	r.HandleFunc("/swapout/{pairid}/{txid}", restapi.GetSwapoutHandler).Methods("GET")
@@ -118,10 +143,23 @@ func initRouter(r *mux.Router) {
 	r.HandleFunc("/swapout/{pairid}/{txid}/rawresult", restapi.GetRawSwapoutResultHandler).Methods("GET")
 	r.HandleFunc("/swapin/history/{pairid}/{address}", restapi.SwapinHistoryHandler).Methods("GET")
 	r.HandleFunc("/swapout/history/{pairid}/{address}", restapi.SwapoutHistoryHandler).Methods("GET")
+	r.HandleFunc("/swap/search", restapi.SearchSwapsHandler).Methods("GET")
+	r.HandleFunc("/swap/swaptx/{swaptx}", restapi.GetSwapBySwapTxHandler).Methods("GET")
 
 	r.HandleFunc("/p2sh/{address}", restapi.GetP2shAddressInfo).Methods("GET")
-	r.HandleFunc("/p2sh/bind/{address}", restapi.RegisterP2shAddress).Methods("POST")
+	r.HandleFunc("/p2sh/bind/{address}", writeHandler(restapi.RegisterP2shAddress)).Methods("POST")
+
+	r.HandleFunc("/destinationtag/{tag}", restapi.GetDestinationTagInfo).Methods("GET")
+	r.HandleFunc("/destinationtag/bind/{address}", restapi.GetBindDestinationTag).Methods("GET")
+	r.HandleFunc("/destinationtag/bind/{address}", writeHandler(restapi.RegisterDestinationTag)).Methods("POST")
 
 	r.HandleFunc("/registered/{address}", restapi.GetRegisteredAddress).Methods("GET")
-	r.HandleFunc("/register/{address}", restapi.RegisterAddress).Methods("POST")
+	r.HandleFunc("/register/{address}", writeHandler(restapi.RegisterAddress)).Methods("POST")
+
+	r.HandleFunc("/admin/pendingswaps", adminReadHandler(restapi.AdminPendingSwapsHandler)).Methods("GET")
+	r.HandleFunc("/admin/dcrmaccounts", adminReadHandler(restapi.AdminDcrmAccountsHandler)).Methods("GET")
+	r.HandleFunc("/admin/blacklist", adminReadHandler(restapi.AdminBlacklistHandler)).Methods("GET")
+	r.HandleFunc("/admin/oraclestatus", adminReadHandler(restapi.AdminOraclesStatusHandler)).Methods("GET")
+
+	registerAPIDocument(r)
 }
@@ -0,0 +1,340 @@
+// Package swapclient provides typed client of the swap json rpc api.
+package swapclient
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/apikey"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/rpc/rpcapi"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+const (
+	defaultTimeout   = 60 // seconds
+	serviceName      = "swap"
+	adminServiceName = "admin"
+)
+
+// type alias of api types
+type (
+	ServerInfo         = swapapi.ServerInfo
+	SwapInfo           = swapapi.SwapInfo
+	Swap               = swapapi.Swap
+	SwapResult         = swapapi.SwapResult
+	SwapNonceInfo      = swapapi.SwapNonceInfo
+	PostResult         = swapapi.PostResult
+	LatestScanInfo     = swapapi.LatestScanInfo
+	RegisteredAddress  = swapapi.RegisteredAddress
+	SearchSwapsArgs    = swapapi.SearchSwapsArgs
+	SearchSwapsResult  = swapapi.SearchSwapsResult
+	SwapBySwapTxResult = swapapi.SwapBySwapTxResult
+	PendingSwap        = swapapi.PendingSwap
+	PairDcrmAccounts   = swapapi.PairDcrmAccounts
+	DcrmAccount        = swapapi.DcrmAccount
+	BlackAccount       = swapapi.BlackAccount
+	OracleStatus       = swapapi.OracleStatus
+)
+
+// Client swap api client
+type Client struct {
+	URL     string // json rpc url, eg. http://127.0.0.1:11556/rpc
+	Timeout int    // seconds
+	APIKey  string // optional, required by admin read methods
+}
+
+// NewClient new client
+func NewClient(url string) *Client {
+	return &Client{
+		URL:     url,
+		Timeout: defaultTimeout,
+	}
+}
+
+func (c *Client) call(result interface{}, method string, params ...interface{}) error {
+	return c.callService(result, serviceName, method, params...)
+}
+
+func (c *Client) callService(result interface{}, service, method string, params ...interface{}) error {
+	req := client.NewRequestWithTimeoutAndID(c.Timeout, 1, service+"."+method, params...)
+	if c.APIKey != "" {
+		req.Headers = map[string]string{apikey.HeaderName: c.APIKey}
+	}
+	return client.RPCPostRequest(c.URL, req, result)
+}
+
+func (c *Client) callWithTxAndPairID(result interface{}, method, txid, pairID, bind string) error {
+	args := &rpcapi.RPCTxAndPairIDArgs{
+		TxID:   txid,
+		PairID: pairID,
+		Bind:   bind,
+	}
+	return c.call(result, method, args)
+}
+
+// GetVersionInfo get version info
+func (c *Client) GetVersionInfo() (result string, err error) {
+	err = c.call(&result, "GetVersionInfo")
+	return result, err
+}
+
+// GetServerInfo get server info
+func (c *Client) GetServerInfo() (result *ServerInfo, err error) {
+	err = c.call(&result, "GetServerInfo")
+	return result, err
+}
+
+// UpdateOracleHeartbeat update oracle heartbeat
+func (c *Client) UpdateOracleHeartbeat(enode string, timestamp int64) (result string, err error) {
+	args := &rpcapi.HeartbeatArgs{
+		Enode:     enode,
+		Timestamp: timestamp,
+	}
+	err = c.call(&result, "UpdateOracleHeartbeat", args)
+	return result, err
+}
+
+// GetOraclesHeartbeat get oracles heartbeat
+func (c *Client) GetOraclesHeartbeat() (result map[string]string, err error) {
+	err = c.call(&result, "GetOraclesHeartbeat")
+	return result, err
+}
+
+// GetStatusInfo get status info, statuses is comma separated status numbers
+func (c *Client) GetStatusInfo(statuses string) (result map[string]map[string]interface{}, err error) {
+	err = c.call(&result, "GetStatusInfo", statuses)
+	return result, err
+}
+
+// GetTokenPairInfo get token pair info
+func (c *Client) GetTokenPairInfo(pairID string) (result *tokens.TokenPairConfig, err error) {
+	err = c.call(&result, "GetTokenPairInfo", pairID)
+	return result, err
+}
+
+// GetTokenPairsInfo get token pairs info, pairIDs is comma separated
+func (c *Client) GetTokenPairsInfo(pairIDs string) (result map[string]*tokens.TokenPairConfig, err error) {
+	err = c.call(&result, "GetTokenPairsInfo", pairIDs)
+	return result, err
+}
+
+// GetCurrentConfigBundle get current config bundle
+func (c *Client) GetCurrentConfigBundle() (result *params.ConfigBundle, err error) {
+	err = c.call(&result, "GetCurrentConfigBundle")
+	return result, err
+}
+
+// GetConfigBundle get published config bundle
+func (c *Client) GetConfigBundle() (result *params.SignedConfigBundle, err error) {
+	err = c.call(&result, "GetConfigBundle")
+	return result, err
+}
+
+// GetNonceInfo get nonce info
+func (c *Client) GetNonceInfo() (result *SwapNonceInfo, err error) {
+	err = c.call(&result, "GetNonceInfo")
+	return result, err
+}
+
+// GetGatewayHealth get gateway health
+func (c *Client) GetGatewayHealth() (result map[string][]*tools.EndpointHealth, err error) {
+	err = c.call(&result, "GetGatewayHealth")
+	return result, err
+}
+
+// GetRawSwapin get raw swapin
+func (c *Client) GetRawSwapin(txid, pairID, bind string) (result *Swap, err error) {
+	err = c.callWithTxAndPairID(&result, "GetRawSwapin", txid, pairID, bind)
+	return result, err
+}
+
+// GetRawSwapinResult get raw swapin result
+func (c *Client) GetRawSwapinResult(txid, pairID, bind string) (result *SwapResult, err error) {
+	err = c.callWithTxAndPairID(&result, "GetRawSwapinResult", txid, pairID, bind)
+	return result, err
+}
+
+// GetSwapin get swapin
+func (c *Client) GetSwapin(txid, pairID, bind string) (result *SwapInfo, err error) {
+	err = c.callWithTxAndPairID(&result, "GetSwapin", txid, pairID, bind)
+	return result, err
+}
+
+// GetRawSwapout get raw swapout
+func (c *Client) GetRawSwapout(txid, pairID, bind string) (result *Swap, err error) {
+	err = c.callWithTxAndPairID(&result, "GetRawSwapout", txid, pairID, bind)
+	return result, err
+}
+
+// GetRawSwapoutResult get raw swapout result
+func (c *Client) GetRawSwapoutResult(txid, pairID, bind string) (result *SwapResult, err error) {
+	err = c.callWithTxAndPairID(&result, "GetRawSwapoutResult", txid, pairID, bind)
+	return result, err
+}
+
+// GetSwapout get swapout
+func (c *Client) GetSwapout(txid, pairID, bind string) (result *SwapInfo, err error) {
+	err = c.callWithTxAndPairID(&result, "GetSwapout", txid, pairID, bind)
+	return result, err
+}
+
+// GetSwapinHistory get swapin history
+func (c *Client) GetSwapinHistory(address, pairID string, offset, limit int, status string) (result []*SwapInfo, err error) {
+	args := &rpcapi.RPCQueryHistoryArgs{
+		Address: address,
+		PairID:  pairID,
+		Offset:  offset,
+		Limit:   limit,
+		Status:  status,
+	}
+	err = c.call(&result, "GetSwapinHistory", args)
+	return result, err
+}
+
+// GetSwapoutHistory get swapout history
+func (c *Client) GetSwapoutHistory(address, pairID string, offset, limit int, status string) (result []*SwapInfo, err error) {
+	args := &rpcapi.RPCQueryHistoryArgs{
+		Address: address,
+		PairID:  pairID,
+		Offset:  offset,
+		Limit:   limit,
+		Status:  status,
+	}
+	err = c.call(&result, "GetSwapoutHistory", args)
+	return result, err
+}
+
+// SearchSwaps search swaps
+func (c *Client) SearchSwaps(args *SearchSwapsArgs) (result *SearchSwapsResult, err error) {
+	err = c.call(&result, "SearchSwaps", args)
+	return result, err
+}
+
+// GetSwapBySwapTx get swaps by swap tx
+func (c *Client) GetSwapBySwapTx(swapTx string) (result *SwapBySwapTxResult, err error) {
+	err = c.call(&result, "GetSwapBySwapTx", swapTx)
+	return result, err
+}
+
+// Swapin post swapin
+func (c *Client) Swapin(txid, pairID string) (result PostResult, err error) {
+	err = c.callWithTxAndPairID(&result, "Swapin", txid, pairID, "")
+	return result, err
+}
+
+// RetrySwapin retry swapin
+func (c *Client) RetrySwapin(txid, pairID string) (result PostResult, err error) {
+	err = c.callWithTxAndPairID(&result, "RetrySwapin", txid, pairID, "")
+	return result, err
+}
+
+// P2shSwapin post p2sh swapin
+func (c *Client) P2shSwapin(txid, bind string) (result PostResult, err error) {
+	args := &rpcapi.RPCP2shSwapinArgs{
+		TxID: txid,
+		Bind: bind,
+	}
+	err = c.call(&result, "P2shSwapin", args)
+	return result, err
+}
+
+// Swapout post swapout
+func (c *Client) Swapout(txid, pairID string) (result PostResult, err error) {
+	err = c.callWithTxAndPairID(&result, "Swapout", txid, pairID, "")
+	return result, err
+}
+
+// IsValidSwapinBindAddress is valid swapin bind address
+func (c *Client) IsValidSwapinBindAddress(address string) (result bool, err error) {
+	err = c.call(&result, "IsValidSwapinBindAddress", address)
+	return result, err
+}
+
+// IsValidSwapoutBindAddress is valid swapout bind address
+func (c *Client) IsValidSwapoutBindAddress(address string) (result bool, err error) {
+	err = c.call(&result, "IsValidSwapoutBindAddress", address)
+	return result, err
+}
+
+// RegisterP2shAddress register p2sh address
+func (c *Client) RegisterP2shAddress(bindAddress string) (result *tokens.P2shAddressInfo, err error) {
+	err = c.call(&result, "RegisterP2shAddress", bindAddress)
+	return result, err
+}
+
+// GetP2shAddressInfo get p2sh address info
+func (c *Client) GetP2shAddressInfo(p2shAddress string) (result *tokens.P2shAddressInfo, err error) {
+	err = c.call(&result, "GetP2shAddressInfo", p2shAddress)
+	return result, err
+}
+
+// RegisterDestinationTag register destination tag
+func (c *Client) RegisterDestinationTag(bindAddress string) (result *tokens.DestinationTagInfo, err error) {
+	err = c.call(&result, "RegisterDestinationTag", bindAddress)
+	return result, err
+}
+
+// GetDestinationTagInfo get destination tag info
+func (c *Client) GetDestinationTagInfo(tag uint32) (result *tokens.DestinationTagInfo, err error) {
+	err = c.call(&result, "GetDestinationTagInfo", tag)
+	return result, err
+}
+
+// GetBindDestinationTag get destination tag of bind address
+func (c *Client) GetBindDestinationTag(bindAddress string) (result *tokens.DestinationTagInfo, err error) {
+	err = c.call(&result, "GetBindDestinationTag", bindAddress)
+	return result, err
+}
+
+// GetLatestScanInfo get latest scan info
+func (c *Client) GetLatestScanInfo(isSrc bool) (result *LatestScanInfo, err error) {
+	err = c.call(&result, "GetLatestScanInfo", isSrc)
+	return result, err
+}
+
+// RegisterAddress register address
+func (c *Client) RegisterAddress(address string) (result PostResult, err error) {
+	err = c.call(&result, "RegisterAddress", address)
+	return result, err
+}
+
+// GetRegisteredAddress get registered address
+func (c *Client) GetRegisteredAddress(address string) (result *RegisteredAddress, err error) {
+	err = c.call(&result, "GetRegisteredAddress", address)
+	return result, err
+}
+
+// AdminCall call admin method with signed raw tx
+func (c *Client) AdminCall(rawTx string) (result string, err error) {
+	err = c.call(&result, "AdminCall", rawTx)
+	return result, err
+}
+
+// GetPendingSwaps get swaps awaiting admin action (adminread api key)
+func (c *Client) GetPendingSwaps(limit int) (result []*PendingSwap, err error) {
+	err = c.callService(&result, adminServiceName, "GetPendingSwaps", limit)
+	return result, err
+}
+
+// GetDcrmAccounts get balances and nonces of dcrm addresses (adminread api key)
+func (c *Client) GetDcrmAccounts() (result []*PairDcrmAccounts, err error) {
+	err = c.callService(&result, adminServiceName, "GetDcrmAccounts")
+	return result, err
+}
+
+// GetBlacklist get blacklist (adminread api key)
+func (c *Client) GetBlacklist(offset, limit int) (result []*BlackAccount, err error) {
+	args := &rpcapi.RPCAdminListArgs{
+		Offset: offset,
+		Limit:  limit,
+	}
+	err = c.callService(&result, adminServiceName, "GetBlacklist", args)
+	return result, err
+}
+
+// GetOraclesStatus get oracles heartbeat status (adminread api key)
+func (c *Client) GetOraclesStatus() (result []*OracleStatus, err error) {
+	err = c.callService(&result, adminServiceName, "GetOraclesStatus")
+	return result, err
+}
@@ -0,0 +1,29 @@
+package swapclient
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/rpc/openapi"
+	"github.com/anyswap/CrossChain-Bridge/rpc/rpcapi"
+)
+
+func TestClientCoversRPCMethods(t *testing.T) {
+	clientType := reflect.TypeOf(new(Client))
+	var methods []reflect.Method
+	methods = append(methods, openapi.GetRPCMethods(new(rpcapi.RPCAPI))...)
+	methods = append(methods, openapi.GetRPCMethods(new(rpcapi.AdminReadAPI))...)
+	for _, method := range methods {
+		clientMethod, exist := clientType.MethodByName(method.Name)
+		if !exist {
+			t.Errorf("client has no method of json rpc method %v", method.Name)
+			continue
+		}
+		// result type of client should be the same as the reply type of service
+		replyType := method.Type.In(3).Elem()
+		resultType := clientMethod.Type.Out(0)
+		if resultType != replyType && (resultType.Kind() != reflect.Ptr || resultType.Elem() != replyType) {
+			t.Errorf("result type mismatch of %v, service %v, client %v", method.Name, replyType, resultType)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package wsapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+
+	// pending messages to a connection, a slow reader exceeding it will be disconnected
+	sendBufferSize = 256
+)
+
+var (
+	errUnknownMethod        = errors.New("unknown method")
+	errUnknownKind          = errors.New("unknown subscription kind, must be one of txid, bind, pairid")
+	errEmptyValue           = errors.New("empty subscription value")
+	errTooManySubscriptions = errors.New("too many subscriptions on this connection")
+	errSubscriptionNotFound = errors.New("subscription not found")
+
+	upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin,
+	}
+)
+
+// request from client, eg.
+// {"id":1,"method":"subscribe","params":{"kind":"txid","value":"0x..."}}
+// {"id":2,"method":"unsubscribe","params":{"subscription":"txid:0x..."}}
+type request struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params requestParams `json:"params"`
+}
+
+type requestParams struct {
+	Kind         string `json:"kind"`
+	Value        string `json:"value"`
+	Subscription string `json:"subscription"`
+}
+
+type response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type notification struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+type wsConn struct {
+	conn      *websocket.Conn
+	send      chan interface{}
+	subs      map[string]struct{} // guarded by hub lock
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	allowedOrigins := params.GetServerConfig().APIServer.AllowedOrigins
+	if len(allowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeWebSocket serve websocket subscription of swap updates
+func ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug("[wsapi] upgrade websocket failed", "remote", r.RemoteAddr, "err", err)
+		return
+	}
+	c := &wsConn{
+		conn: conn,
+		send: make(chan interface{}, sendBufferSize),
+		subs: make(map[string]struct{}),
+		quit: make(chan struct{}),
+	}
+	if !theHub.addConn(c) {
+		log.Warn("[wsapi] too many websocket connections", "remote", r.RemoteAddr)
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many connections"),
+			time.Now().Add(writeWait))
+		_ = conn.Close()
+		return
+	}
+	go c.writeLoop()
+	c.readLoop()
+}
+
+// push message without blocking, disconnect the slow reader if buffer is full
+func (c *wsConn) push(msg interface{}) {
+	select {
+	case <-c.quit:
+	case c.send <- msg:
+	default:
+		log.Warn("[wsapi] websocket send buffer is full, close slow connection", "remote", c.conn.RemoteAddr())
+		c.close()
+	}
+}
+
+func (c *wsConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		theHub.removeConn(c)
+		_ = c.conn.Close()
+	})
+}
+
+func (c *wsConn) readLoop() {
+	defer c.close()
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		var req request
+		err := c.conn.ReadJSON(&req)
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Debug("[wsapi] read websocket message failed", "remote", c.conn.RemoteAddr(), "err", err)
+			}
+			return
+		}
+		c.push(c.handleRequest(&req))
+	}
+}
+
+func (c *wsConn) handleRequest(req *request) *response {
+	var result interface{}
+	var err error
+	switch req.Method {
+	case "subscribe":
+		result, err = theHub.subscribe(c, req.Params.Kind, req.Params.Value)
+	case "unsubscribe":
+		err = theHub.unsubscribe(c, req.Params.Subscription)
+		result = err == nil
+	default:
+		err = errUnknownMethod
+	}
+	if err != nil {
+		return &response{ID: req.ID, Error: err.Error()}
+	}
+	return &response{ID: req.ID, Result: result}
+}
+
+func (c *wsConn) writeLoop() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.close()
+	}()
+	for {
+		select {
+		case <-c.quit:
+			return
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Package wsapi provides websocket api to subscribe swap updates.
+package wsapi
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+)
+
+// subscription kinds
+const (
+	SubscribeTxID   = "txid"
+	SubscribeBind   = "bind"
+	SubscribePairID = "pairid"
+)
+
+const (
+	defaultMaxWebSocketConns       = 1000
+	defaultMaxSubscriptionsPerConn = 100
+
+	swapEventQueueSize = 4096
+)
+
+var theHub = &hub{
+	conns:  make(map[*wsConn]struct{}),
+	subs:   make(map[string]map[*wsConn]struct{}),
+	events: make(chan *swapEvent, swapEventQueueSize),
+}
+
+type hub struct {
+	lock   sync.RWMutex
+	conns  map[*wsConn]struct{}
+	subs   map[string]map[*wsConn]struct{} // key is subscription id
+	events chan *swapEvent
+}
+
+type swapEvent struct {
+	isSwapin bool
+	txid     string
+	pairID   string
+	bind     string
+}
+
+func getSubscriptionID(kind, value string) string {
+	return kind + ":" + strings.ToLower(value)
+}
+
+func isValidKind(kind string) bool {
+	switch kind {
+	case SubscribeTxID, SubscribeBind, SubscribePairID:
+		return true
+	default:
+		return false
+	}
+}
+
+func getMaxWebSocketConns() int {
+	if maxConns := params.GetServerConfig().APIServer.MaxWebSocketConns; maxConns > 0 {
+		return maxConns
+	}
+	return defaultMaxWebSocketConns
+}
+
+func getMaxSubscriptionsPerConn() int {
+	if maxSubs := params.GetServerConfig().APIServer.MaxSubscriptionsPerConn; maxSubs > 0 {
+		return maxSubs
+	}
+	return defaultMaxSubscriptionsPerConn
+}
+
+// StartSubscriptionHub start dispatching swap updates to subscribers
+func StartSubscriptionHub() {
+	mongodb.SwapUpdatedHook = theHub.notify
+	go theHub.loop()
+}
+
+// CloseAllConns close all websocket connections
+func CloseAllConns() {
+	theHub.lock.RLock()
+	conns := make([]*wsConn, 0, len(theHub.conns))
+	for c := range theHub.conns {
+		conns = append(conns, c)
+	}
+	theHub.lock.RUnlock()
+	for _, c := range conns {
+		c.close()
+	}
+}
+
+// notify is called in the database updating routine, so never block it
+func (h *hub) notify(isSwapin bool, txid, pairID, bind string) {
+	select {
+	case h.events <- &swapEvent{isSwapin: isSwapin, txid: txid, pairID: pairID, bind: bind}:
+	default:
+		log.Warn("[wsapi] swap event queue is full, drop event", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin)
+	}
+}
+
+func (h *hub) loop() {
+	for ev := range h.events {
+		h.dispatch(ev)
+	}
+}
+
+func (h *hub) dispatch(ev *swapEvent) {
+	subIDs := []string{
+		getSubscriptionID(SubscribeTxID, ev.txid),
+		getSubscriptionID(SubscribeBind, ev.bind),
+		getSubscriptionID(SubscribePairID, ev.pairID),
+	}
+	if !h.hasSubscribers(subIDs) {
+		return
+	}
+	swapInfo, err := getSwapInfo(ev)
+	if err != nil {
+		log.Warn("[wsapi] get swap info failed", "txid", ev.txid, "pairID", ev.pairID, "bind", ev.bind, "isSwapin", ev.isSwapin, "err", err)
+		return
+	}
+	type target struct {
+		conn  *wsConn
+		subID string
+	}
+	var targets []target
+	h.lock.RLock()
+	for _, subID := range subIDs {
+		for c := range h.subs[subID] {
+			targets = append(targets, target{conn: c, subID: subID})
+		}
+	}
+	h.lock.RUnlock()
+	for _, t := range targets {
+		t.conn.push(&notification{Subscription: t.subID, Result: swapInfo})
+	}
+}
+
+func (h *hub) hasSubscribers(subIDs []string) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for _, subID := range subIDs {
+		if len(h.subs[subID]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// prefer swap result, as swap result has the swap tx info
+func getSwapInfo(ev *swapEvent) (*swapapi.SwapInfo, error) {
+	res, err := mongodb.FindSwapResult(ev.isSwapin, ev.txid, ev.pairID, ev.bind)
+	if err == nil {
+		return swapapi.ConvertMgoSwapResultToSwapInfo(res), nil
+	}
+	swap, err := mongodb.FindSwap(ev.isSwapin, ev.txid, ev.pairID, ev.bind)
+	if err != nil {
+		return nil, err
+	}
+	return swapapi.ConvertMgoSwapToSwapInfo(swap), nil
+}
+
+func (h *hub) addConn(c *wsConn) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if len(h.conns) >= getMaxWebSocketConns() {
+		return false
+	}
+	h.conns[c] = struct{}{}
+	return true
+}
+
+func (h *hub) removeConn(c *wsConn) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for subID := range c.subs {
+		h.removeSubscription(c, subID)
+	}
+	delete(h.conns, c)
+}
+
+func (h *hub) subscribe(c *wsConn, kind, value string) (string, error) {
+	if !isValidKind(kind) {
+		return "", errUnknownKind
+	}
+	if value == "" {
+		return "", errEmptyValue
+	}
+	subID := getSubscriptionID(kind, value)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, exist := c.subs[subID]; exist {
+		return subID, nil
+	}
+	if len(c.subs) >= getMaxSubscriptionsPerConn() {
+		return "", errTooManySubscriptions
+	}
+	c.subs[subID] = struct{}{}
+	conns, exist := h.subs[subID]
+	if !exist {
+		conns = make(map[*wsConn]struct{})
+		h.subs[subID] = conns
+	}
+	conns[c] = struct{}{}
+	return subID, nil
+}
+
+func (h *hub) unsubscribe(c *wsConn, subID string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, exist := c.subs[subID]; !exist {
+		return errSubscriptionNotFound
+	}
+	h.removeSubscription(c, subID)
+	return nil
+}
+
+// removeSubscription must be called with lock held
+func (h *hub) removeSubscription(c *wsConn, subID string) {
+	delete(c.subs, subID)
+	if conns, exist := h.subs[subID]; exist {
+		delete(conns, c)
+		if len(conns) == 0 {
+			delete(h.subs, subID)
+		}
+	}
+}
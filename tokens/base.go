@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	"fmt"
 	"math"
 	"math/big"
 
@@ -16,11 +17,17 @@ const (
 	AggregateMemo    = "aggregate"
 
 	MaxPlusGasPricePercentage = uint64(100)
+
+	defaultTokenPriceTolerance = 0.05
+
+	SwapFeeModeGasCost = "gascost"
 )
 
 // common variables
 var (
-	AggregateIdentifier = "aggregate"
+	AggregateIdentifier   = "aggregate"
+	TrustSetIdentifier    = "trustset"
+	CancelNonceIdentifier = "cancelnonce"
 
 	SrcBridge CrossChainBridge
 	DstBridge CrossChainBridge
@@ -69,6 +76,9 @@ func (b *CrossChainBridgeBase) SetChainAndGateway(chainCfg *ChainConfig, gateway
 	if len(gatewayCfg.APIAddress) == 0 {
 		log.Fatal("empty gateway 'APIAddress'")
 	}
+	if err := gatewayCfg.InitQuorum(); err != nil {
+		log.Fatal("init gateway quorum failed", "err", err)
+	}
 }
 
 // GetChainConfig get chain config
@@ -184,21 +194,100 @@ func CheckSwapValue(inf *TxSwapInfo, isSrc bool) bool {
 	return CalcSwappedValue(inf.PairID, inf.Value, isSrc, inf.From, inf.TxTo).Sign() > 0
 }
 
+// GetTokenPrice get token price used to convert USD denominated settings
+func GetTokenPrice(pairID string, isSrc bool) float64 {
+	token := GetTokenConfig(pairID, isSrc)
+	if token == nil {
+		return 0
+	}
+	return token.TokenPrice
+}
+
+// CheckTokenPrice check token price is in tolerance range of the local token price
+func CheckTokenPrice(pairID string, isSrc bool, tokenPrice float64) error {
+	if tokenPrice == 0 {
+		return nil
+	}
+	localPrice := GetTokenPrice(pairID, isSrc)
+	if localPrice <= 0 {
+		return fmt.Errorf("%w: have %v, but local token price is not loaded", ErrTokenPriceMismatch, tokenPrice)
+	}
+	tolerance := getTolerance()
+	if math.Abs(tokenPrice-localPrice) > localPrice*tolerance {
+		return fmt.Errorf("%w: have %v, local %v, tolerance %v", ErrTokenPriceMismatch, tokenPrice, localPrice, tolerance)
+	}
+	return nil
+}
+
+// CheckNativePrice check native price is in tolerance range of the local native price
+func CheckNativePrice(chainCfg *ChainConfig, nativePrice float64) error {
+	localPrice := chainCfg.GetNativePrice()
+	if localPrice <= 0 {
+		return fmt.Errorf("%w: have %v, but local native price is not loaded", ErrTokenPriceMismatch, nativePrice)
+	}
+	tolerance := getTolerance()
+	if math.Abs(nativePrice-localPrice) > localPrice*tolerance {
+		return fmt.Errorf("%w: native price have %v, local %v, tolerance %v", ErrTokenPriceMismatch, nativePrice, localPrice, tolerance)
+	}
+	return nil
+}
+
+func getTolerance() float64 {
+	if TokenPriceCfg != nil && TokenPriceCfg.Tolerance > 0 {
+		return TokenPriceCfg.Tolerance
+	}
+	return defaultTokenPriceTolerance
+}
+
+// CheckValueTolerance check value is in tolerance range of the local value
+func CheckValueTolerance(value, localValue *big.Int) error {
+	diff := new(big.Int).Sub(value, localValue)
+	diff.Abs(diff)
+	maxDiff, _ := new(big.Float).Mul(new(big.Float).SetInt(localValue), big.NewFloat(getTolerance())).Int(nil)
+	if diff.Cmp(maxDiff) > 0 {
+		return fmt.Errorf("%w: have %v, local %v, tolerance %v", ErrValueOutOfTolerance, value, localValue, getTolerance())
+	}
+	return nil
+}
+
+// IsGasCostFeeMode is swap fee including gas cost on destination chain
+func IsGasCostFeeMode(pairID string, isSrc bool) bool {
+	token := GetTokenConfig(pairID, isSrc)
+	return token != nil && token.IsGasCostFeeMode()
+}
+
 // CalcSwappedValue calc swapped value (get rid of fee)
 func CalcSwappedValue(pairID string, value *big.Int, isSrc bool, from, txto string) *big.Int {
+	return CalcSwappedValueWithPrice(pairID, value, isSrc, from, txto, 0)
+}
+
+// CalcSwappedValueWithPrice calc swapped value with the specified token price,
+// use the local token price if tokenPrice is zero.
+func CalcSwappedValueWithPrice(pairID string, value *big.Int, isSrc bool, from, txto string, tokenPrice float64) *big.Int {
+	token, cpToken := GetTokenConfigsByDirection(pairID, isSrc)
+	return calcSwappedValue(pairID, token, cpToken, value, isSrc, from, txto, tokenPrice)
+}
+
+// CalcSwappedValueOfSwap calc swapped value with the pair config used by the swap
+func CalcSwappedValueOfSwap(swap *SwapInfo, value *big.Int, from, txto string, tokenPrice float64) *big.Int {
+	isSrc := swap.IsSwapin()
+	return calcSwappedValue(swap.PairID, swap.GetTokenConfig(isSrc), swap.GetTokenConfig(!isSrc), value, isSrc, from, txto, tokenPrice)
+}
+
+func calcSwappedValue(pairID string, token, cpToken *TokenConfig, value *big.Int, isSrc bool, from, txto string, tokenPrice float64) *big.Int {
 	if value == nil || value.Sign() <= 0 {
 		return big.NewInt(0)
 	}
 
-	token, cpToken := GetTokenConfigsByDirection(pairID, isSrc)
+	values := token.getSwapValues(tokenPrice)
 
-	if value.Cmp(token.minSwap) < 0 {
+	if value.Cmp(values.minSwap) < 0 {
 		return big.NewInt(0)
 	}
 
 	isInBigValueWhitelist := token.IsInBigValueWhitelist(from) || token.IsInBigValueWhitelist(txto)
 
-	if !isInBigValueWhitelist && value.Cmp(token.maxSwap) > 0 {
+	if !isInBigValueWhitelist && value.Cmp(values.maxSwap) > 0 {
 		return big.NewInt(0)
 	}
 
@@ -209,22 +298,22 @@ func CalcSwappedValue(pairID string, value *big.Int, isSrc bool, from, txto stri
 	var swapFee, adjustBaseFee *big.Int
 
 	if isInBigValueWhitelist {
-		swapFee = token.minSwapFee
+		swapFee = values.minSwapFee
 	} else {
 		feeRateMul1e18 := new(big.Int).SetUint64(uint64(*token.SwapFeeRate * 1e18))
 		swapFee = new(big.Int).Mul(value, feeRateMul1e18)
 		swapFee.Div(swapFee, big.NewInt(1e18))
 
-		if swapFee.Cmp(token.minSwapFee) < 0 {
-			swapFee = token.minSwapFee
-		} else if swapFee.Cmp(token.maxSwapFee) > 0 {
-			swapFee = token.maxSwapFee
+		if swapFee.Cmp(values.minSwapFee) < 0 {
+			swapFee = values.minSwapFee
+		} else if swapFee.Cmp(values.maxSwapFee) > 0 {
+			swapFee = values.maxSwapFee
 		}
 
-		if GetNonceSetter(!isSrc) != nil { // eth-like
+		if GetNonceSetter(!isSrc) != nil && !token.IsGasCostFeeMode() { // eth-like
 			chainCfg := GetCrossChainBridge(!isSrc).GetChainConfig()
-			if chainCfg.BaseFeePercent != 0 && token.minSwapFee.Sign() > 0 {
-				adjustBaseFee = new(big.Int).Set(token.minSwapFee)
+			if chainCfg.BaseFeePercent != 0 && values.minSwapFee.Sign() > 0 {
+				adjustBaseFee = new(big.Int).Set(values.minSwapFee)
 				adjustBaseFee.Mul(adjustBaseFee, big.NewInt(chainCfg.BaseFeePercent))
 				adjustBaseFee.Div(adjustBaseFee, big.NewInt(100))
 				swapFee = new(big.Int).Add(swapFee, adjustBaseFee)
@@ -237,13 +326,13 @@ func CalcSwappedValue(pairID string, value *big.Int, isSrc bool, from, txto stri
 
 	if value.Cmp(swapFee) <= 0 {
 		log.Warn("check swap value failed", "pairID", pairID, "value", value, "isSrc", isSrc,
-			"minSwapFee", token.minSwapFee, "adjustBaseFee", adjustBaseFee, "swapFee", swapFee)
+			"minSwapFee", values.minSwapFee, "adjustBaseFee", adjustBaseFee, "swapFee", swapFee)
 		return big.NewInt(0)
 	}
 
 	swappedValue := new(big.Int).Sub(value, swapFee)
 	// recheck swap value range
-	if swappedValue.Cmp(value) > 0 || (!isInBigValueWhitelist && swappedValue.Cmp(token.maxSwap) > 0) {
+	if swappedValue.Cmp(value) > 0 || (!isInBigValueWhitelist && swappedValue.Cmp(values.maxSwap) > 0) {
 		return big.NewInt(0)
 	}
 	return ConvertTokenValue(swappedValue, *token.Decimals, *cpToken.Decimals)
@@ -2,6 +2,7 @@ package base
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
@@ -13,6 +14,9 @@ type NonceSetterBase struct {
 	*tokens.CrossChainBridgeBase
 	SwapinNonce  map[string]uint64
 	SwapoutNonce map[string]uint64
+
+	// nonces are accessed by the task goroutines of all senders
+	nonceLock sync.RWMutex
 }
 
 // NewNonceSetterBase new base nonce setter
@@ -27,7 +31,14 @@ func NewNonceSetterBase(isSrc bool) *NonceSetterBase {
 // SetNonce set nonce directly always increase
 func (b *NonceSetterBase) SetNonce(pairID string, value uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
-	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.SetSenderNonce(tokenCfg.DcrmAddress, value)
+}
+
+// SetSenderNonce set nonce of sender directly always increase
+func (b *NonceSetterBase) SetSenderNonce(sender string, value uint64) {
+	account := strings.ToLower(sender)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
 	if b.IsSrcEndpoint() {
 		if b.SwapoutNonce[account] < value {
 			b.SwapoutNonce[account] = value
@@ -44,8 +55,15 @@ func (b *NonceSetterBase) SetNonce(pairID string, value uint64) {
 // AdjustNonce adjust account nonce (eth like chain)
 func (b *NonceSetterBase) AdjustNonce(pairID string, value uint64) (nonce uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
-	account := strings.ToLower(tokenCfg.DcrmAddress)
+	return b.AdjustSenderNonce(tokenCfg.DcrmAddress, value)
+}
+
+// AdjustSenderNonce adjust nonce of sender (eth like chain)
+func (b *NonceSetterBase) AdjustSenderNonce(sender string, value uint64) (nonce uint64) {
+	account := strings.ToLower(sender)
 	nonce = value
+	b.nonceLock.RLock()
+	defer b.nonceLock.RUnlock()
 	if b.IsSrcEndpoint() {
 		if b.SwapoutNonce[account] > value {
 			nonce = b.SwapoutNonce[account]
@@ -60,6 +78,8 @@ func (b *NonceSetterBase) AdjustNonce(pairID string, value uint64) (nonce uint64
 
 // InitNonces init nonces
 func (b *NonceSetterBase) InitNonces(nonces map[string]uint64) {
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
 	if b.IsSrcEndpoint() {
 		b.SwapoutNonce = nonces
 	} else {
@@ -10,6 +10,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/wallet/txsizes"
@@ -41,7 +42,7 @@ func (b *Bridge) getRelayFeePerKb() (estimateFee int64) {
 func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	var (
 		pairID        = args.PairID
-		token         = b.GetTokenConfig(pairID)
+		token         = args.GetTokenConfig(b.IsSrcEndpoint())
 		from          string
 		to            string
 		changeAddress string
@@ -62,7 +63,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		to = args.Bind                    // to
 		changeAddress = token.DcrmAddress // change
 
-		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false, args.OriginFrom, args.OriginTxTo) // amount
+		amount = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, args.OriginFrom, args.OriginTxTo, args.TokenPrice) // amount
 		memo = tokens.UnlockMemoPrefix + args.SwapID
 	default:
 		return nil, tokens.ErrUnknownSwapType
@@ -96,11 +97,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	reserver := tools.GetUtxoReserver(args)
+	needReserve := len(extra.PreviousOutPoints) == 0
+
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
 		if len(extra.PreviousOutPoints) != 0 {
 			return b.getUtxos(from, target, extra.PreviousOutPoints)
 		}
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, reserver)
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -114,6 +118,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 
 	updateExtraInfo(extra, authoredTx.Tx.TxIn)
 
+	if needReserve {
+		err = tools.ReserveUtxos(reserver, from, extra.PreviousOutPoints)
+		if err != nil {
+			extra.PreviousOutPoints = nil
+			return nil, err
+		}
+	}
+
 	if args.SwapType != tokens.NoSwapType {
 		args.Identifier = params.GetIdentifier()
 	}
@@ -125,14 +137,19 @@ func updateExtraInfo(extra *tokens.BtcExtraArgs, txins []*wireTxInType) {
 	if len(extra.PreviousOutPoints) > 0 {
 		return
 	}
-	extra.PreviousOutPoints = make([]*tokens.BtcOutPoint, len(txins))
+	extra.PreviousOutPoints = getTxInOutPoints(txins)
+}
+
+func getTxInOutPoints(txins []*wireTxInType) []*tokens.BtcOutPoint {
+	points := make([]*tokens.BtcOutPoint, len(txins))
 	for i, txin := range txins {
 		point := txin.PreviousOutPoint
-		extra.PreviousOutPoints[i] = &tokens.BtcOutPoint{
+		points[i] = &tokens.BtcOutPoint{
 			Hash:  point.Hash.String(),
 			Index: point.Index,
 		}
 	}
+	return points
 }
 
 // BuildTransaction build tx
@@ -156,7 +173,7 @@ func (b *Bridge) BuildTransaction(from string, receivers []string, amounts []int
 	}
 
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, "")
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -241,7 +258,7 @@ func (b *Bridge) getOutspendWithRetry(point *tokens.BtcOutPoint) (outspend *elec
 	return outspend, err
 }
 
-func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
+func (b *Bridge) selectUtxos(from string, target btcAmountType, reserver string) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
 	p2pkhScript, err := b.GetPayToAddrScript(from)
 	if err != nil {
 		return 0, nil, nil, nil, err
@@ -262,6 +279,9 @@ func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmount
 		if !isValidValue(value) {
 			continue
 		}
+		if tools.IsUtxoReservedByOthers(*utxo.Txid, *utxo.Vout, reserver) {
+			continue
+		}
 		tx, err = b.getTransactionByHashWithRetry(*utxo.Txid)
 		if err != nil {
 			continue
@@ -2,9 +2,12 @@ package btc
 
 import (
 	"errors"
+	"strings"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
 const (
@@ -46,28 +49,28 @@ func (b *Bridge) AggregateUtxos(addrs []string, utxos []*electrs.ElectUtxo) (str
 
 	extra := args.Extra.BtcExtra
 	extra.RelayFeePerKb = &relayFee
-	extra.PreviousOutPoints = make([]*tokens.BtcOutPoint, len(authoredTx.Tx.TxIn))
-	for i, txin := range authoredTx.Tx.TxIn {
-		point := txin.PreviousOutPoint
-		extra.PreviousOutPoints[i] = &tokens.BtcOutPoint{
-			Hash:  point.Hash.String(),
-			Index: point.Index,
-		}
+	extra.PreviousOutPoints = getTxInOutPoints(authoredTx.Tx.TxIn)
+
+	tokenCfg := b.GetTokenConfig(PairID)
+	err = tools.ReserveUtxos(tokens.AggregateIdentifier, tokenCfg.DcrmAddress, extra.PreviousOutPoints)
+	if err != nil {
+		return "", err
 	}
 
 	var signedTx interface{}
 	var txHash string
-	tokenCfg := b.GetTokenConfig(PairID)
 	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
 		signedTx, txHash, err = b.SignTransaction(authoredTx, PairID)
 	} else {
 		signedTx, txHash, err = b.DcrmSignTransaction(authoredTx, args)
 	}
 	if err != nil {
+		tools.ReleaseUtxos(extra.PreviousOutPoints)
 		return "", err
 	}
 	_, err = b.SendTransaction(signedTx)
 	if err != nil {
+		tools.ReleaseUtxos(extra.PreviousOutPoints)
 		return "", err
 	}
 	return txHash, nil
@@ -87,3 +90,25 @@ func (b *Bridge) VerifyAggregateMsgHash(msgHash []string, args *tokens.BuildTxAr
 	}
 	return b.VerifyMsgHash(rawTx, msgHash)
 }
+
+// IsAggregateTx is aggregate tx which has memo output of aggregate memo
+func (b *Bridge) IsAggregateTx(txHash string) (bool, error) {
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		return false, err
+	}
+	for _, output := range tx.Vout {
+		if output.ScriptpubkeyType == nil || *output.ScriptpubkeyType != opReturnType || output.ScriptpubkeyAsm == nil {
+			continue
+		}
+		parts := regexMemo.Split(*output.ScriptpubkeyAsm, -1)
+		if len(parts) != 2 {
+			continue
+		}
+		memo := common.FromHex(strings.TrimSpace(parts[1]))
+		if string(memo) == tokens.AggregateMemo {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/coinselect"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/wallet/txsizes"
@@ -51,7 +53,7 @@ func (b *Bridge) getRelayFeePerKb() (estimateFee int64, err error) {
 func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	var (
 		pairID        = args.PairID
-		token         = b.GetTokenConfig(pairID)
+		token         = args.GetTokenConfig(b.IsSrcEndpoint())
 		from          string
 		to            string
 		changeAddress string
@@ -72,7 +74,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		to = args.Bind                    // to
 		changeAddress = token.DcrmAddress // change
 
-		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false, args.OriginFrom, args.OriginTxTo) // amount
+		amount = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, args.OriginFrom, args.OriginTxTo, args.TokenPrice) // amount
 		memo = tokens.UnlockMemoPrefix + args.SwapID
 	default:
 		return nil, tokens.ErrUnknownSwapType
@@ -109,11 +111,20 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	reserver := tools.GetUtxoReserver(args)
+	needReserve := len(extra.PreviousOutPoints) == 0
+	selector := &utxoSelector{
+		reserver:      reserver,
+		strategy:      cfgCoinSelection,
+		outputs:       txOuts,
+		relayFeePerKb: relayFeePerKb,
+	}
+
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
 		if len(extra.PreviousOutPoints) != 0 {
 			return b.getUtxos(from, target, extra.PreviousOutPoints)
 		}
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, selector)
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -127,6 +138,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 
 	updateExtraInfo(extra, authoredTx.Tx.TxIn)
 
+	if needReserve {
+		err = tools.ReserveUtxos(reserver, from, extra.PreviousOutPoints)
+		if err != nil {
+			extra.PreviousOutPoints = nil
+			return nil, err
+		}
+	}
+
 	if args.SwapType != tokens.NoSwapType {
 		args.Identifier = params.GetIdentifier()
 	}
@@ -138,14 +157,19 @@ func updateExtraInfo(extra *tokens.BtcExtraArgs, txins []*wireTxInType) {
 	if len(extra.PreviousOutPoints) > 0 {
 		return
 	}
-	extra.PreviousOutPoints = make([]*tokens.BtcOutPoint, len(txins))
+	extra.PreviousOutPoints = getTxInOutPoints(txins)
+}
+
+func getTxInOutPoints(txins []*wireTxInType) []*tokens.BtcOutPoint {
+	points := make([]*tokens.BtcOutPoint, len(txins))
 	for i, txin := range txins {
 		point := txin.PreviousOutPoint
-		extra.PreviousOutPoints[i] = &tokens.BtcOutPoint{
+		points[i] = &tokens.BtcOutPoint{
 			Hash:  point.Hash.String(),
 			Index: point.Index,
 		}
 	}
+	return points
 }
 
 // BuildTransaction build tx
@@ -169,7 +193,7 @@ func (b *Bridge) BuildTransaction(from string, receivers []string, amounts []int
 	}
 
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, &utxoSelector{})
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -254,7 +278,7 @@ func (b *Bridge) getOutspendWithRetry(point *tokens.BtcOutPoint) (outspend *elec
 	return outspend, err
 }
 
-func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
+func (b *Bridge) selectUtxos(from string, target btcAmountType, selector *utxoSelector) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
 	p2pkhScript, err := b.GetPayToAddrScript(from)
 	if err != nil {
 		return 0, nil, nil, nil, err
@@ -265,28 +289,21 @@ func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmount
 		return 0, nil, nil, nil, err
 	}
 
-	var (
-		tx      *electrs.ElectTx
-		success bool
-	)
+	if !selector.isGreedy() {
+		return b.selectUtxosWithStrategy(from, target, selector, utxos, p2pkhScript)
+	}
+
+	var success bool
 
 	for _, utxo := range utxos {
 		value := btcAmountType(*utxo.Value)
 		if !isValidValue(value) {
 			continue
 		}
-		tx, err = b.getTransactionByHashWithRetry(*utxo.Txid)
-		if err != nil {
-			continue
-		}
-		if *utxo.Vout >= uint32(len(tx.Vout)) {
+		if tools.IsUtxoReservedByOthers(*utxo.Txid, *utxo.Vout, selector.reserver) {
 			continue
 		}
-		output := tx.Vout[*utxo.Vout]
-		if *output.ScriptpubkeyType != p2pkhType {
-			continue
-		}
-		if output.ScriptpubkeyAddress == nil || *output.ScriptpubkeyAddress != from {
+		if !b.isSpendableUtxo(from, utxo) {
 			continue
 		}
 
@@ -311,6 +328,7 @@ func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmount
 		return 0, nil, nil, nil, err
 	}
 
+	log.Info(b.ChainConfig.BlockChain+" select utxos", "from", from, "reserver", selector.reserver, "target", target, "strategy", coinselect.Greedy, "inputs", len(inputs), "total", total)
 	return total, inputs, inputValues, scripts, nil
 }
 
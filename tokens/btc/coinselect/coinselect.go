@@ -0,0 +1,359 @@
+// Package coinselect implements utxo coin selection strategies.
+package coinselect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// coin selection strategy names
+const (
+	Greedy         = "greedy"
+	BranchAndBound = "bnb"
+	LargestFirst   = "largestfirst"
+	Consolidate    = "consolidate"
+	Auto           = "auto"
+)
+
+const bnbMaxTries = 100000
+
+// Candidate utxo candidate
+type Candidate struct {
+	Index int // index in the caller's utxo list
+	Value uint64
+}
+
+// Params coin selection parameters
+type Params struct {
+	Amount        uint64 // total value of outputs
+	BaseFee       uint64 // fee of tx without any input (including change output)
+	InputFee      uint64 // fee of spending one input
+	MinFee        uint64 // minimum fee of tx
+	MinTotal      uint64 // total input value must not be less than this
+	CostOfChange  uint64 // excess under this value is dropped as fee (no change output)
+	FeeRatePerKb  int64
+	HighFeeRate   int64  // fee rate at or above this is high fee
+	LowFeeRate    int64  // fee rate at or below this is low fee
+	MaxInputs     int    // maximum number of inputs when consolidating
+	DustThreshold uint64 // only consolidate utxos not larger than this
+
+	// EstimateFee estimate fee with the specified input count,
+	// if not set, use `BaseFee + inputCount * InputFee` instead
+	EstimateFee func(inputCount int) uint64
+}
+
+// RequiredTotal required total input value with the specified input count
+func (p *Params) RequiredTotal(inputCount int) uint64 {
+	var fee uint64
+	if p.EstimateFee != nil {
+		fee = p.EstimateFee(inputCount)
+	} else {
+		fee = p.BaseFee + uint64(inputCount)*p.InputFee
+	}
+	if fee < p.MinFee {
+		fee = p.MinFee
+	}
+	required := p.Amount + fee
+	if required < p.MinTotal {
+		required = p.MinTotal
+	}
+	return required
+}
+
+// IsHighFee is high fee rate
+func (p *Params) IsHighFee() bool {
+	return p.HighFeeRate > 0 && p.FeeRatePerKb >= p.HighFeeRate
+}
+
+// IsLowFee is low fee rate
+func (p *Params) IsLowFee() bool {
+	return p.LowFeeRate > 0 && p.FeeRatePerKb <= p.LowFeeRate
+}
+
+// Result coin selection result
+type Result struct {
+	Strategy string
+	Selected []*Candidate
+	Total    uint64
+	Required uint64
+	Decision string
+}
+
+// Excess total input value exceeds the required
+func (r *Result) Excess() uint64 {
+	if r.Total > r.Required {
+		return r.Total - r.Required
+	}
+	return 0
+}
+
+// String impl Stringer
+func (r *Result) String() string {
+	return fmt.Sprintf("strategy %v, inputs %v, total %v, required %v, excess %v, decision %v",
+		r.Strategy, len(r.Selected), r.Total, r.Required, r.Excess(), r.Decision)
+}
+
+// SelectFunc coin selection function.
+// candidates are in the caller's preferred order, return nil if no solution.
+type SelectFunc func(candidates []*Candidate, params *Params) *Result
+
+var (
+	selectors    = make(map[string]SelectFunc)
+	selectorLock sync.RWMutex
+)
+
+func init() {
+	Register(Greedy, selectGreedy)
+	Register(BranchAndBound, selectBranchAndBound)
+	Register(LargestFirst, selectLargestFirst)
+	Register(Consolidate, selectConsolidate)
+	Register(Auto, selectAuto)
+}
+
+// Register register coin selection strategy
+func Register(name string, fn SelectFunc) {
+	selectorLock.Lock()
+	defer selectorLock.Unlock()
+	selectors[strings.ToLower(name)] = fn
+}
+
+// IsSupported is coin selection strategy supported
+func IsSupported(name string) bool {
+	return getSelector(name) != nil
+}
+
+func getSelector(name string) SelectFunc {
+	selectorLock.RLock()
+	defer selectorLock.RUnlock()
+	if name == "" {
+		name = Greedy
+	}
+	return selectors[strings.ToLower(name)]
+}
+
+// Select select coins with the specified strategy
+func Select(strategy string, candidates []*Candidate, params *Params) (*Result, error) {
+	fn := getSelector(strategy)
+	if fn == nil {
+		return nil, fmt.Errorf("unsupported coin selection strategy '%v'", strategy)
+	}
+	result := fn(candidates, params)
+	if result == nil {
+		var total uint64
+		for _, c := range candidates {
+			total += c.Value
+		}
+		return nil, fmt.Errorf("not enough balance, total %v < target %v", total, params.RequiredTotal(len(candidates)))
+	}
+	return result, nil
+}
+
+func newResult(strategy string, selected []*Candidate, params *Params, decision string) *Result {
+	result := &Result{
+		Strategy: strategy,
+		Selected: selected,
+		Required: params.RequiredTotal(len(selected)),
+		Decision: decision,
+	}
+	for _, c := range selected {
+		result.Total += c.Value
+	}
+	return result
+}
+
+// accumulate candidates in order until the required total is reached
+func accumulate(strategy string, candidates []*Candidate, params *Params, decision string) *Result {
+	var (
+		selected []*Candidate
+		total    uint64
+	)
+	for _, c := range candidates {
+		selected = append(selected, c)
+		total += c.Value
+		if total >= params.RequiredTotal(len(selected)) {
+			return newResult(strategy, selected, params, decision)
+		}
+	}
+	return nil
+}
+
+// effective value of candidate (value minus fee of spending it)
+func effectiveValue(c *Candidate, params *Params) int64 {
+	return int64(c.Value) - int64(params.InputFee)
+}
+
+func sortByValueDesc(candidates []*Candidate) []*Candidate {
+	sorted := make([]*Candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+	return sorted
+}
+
+// selectGreedy take candidates in the caller's order
+func selectGreedy(candidates []*Candidate, params *Params) *Result {
+	return accumulate(Greedy, candidates, params, "take utxos in default order")
+}
+
+// selectLargestFirst take the largest candidates first, skip uneconomic ones
+func selectLargestFirst(candidates []*Candidate, params *Params) *Result {
+	sorted := sortByValueDesc(candidates)
+	economic := make([]*Candidate, 0, len(sorted))
+	for _, c := range sorted {
+		if effectiveValue(c, params) > 0 {
+			economic = append(economic, c)
+		}
+	}
+	decision := fmt.Sprintf("take largest utxos first, skip %v uneconomic utxos", len(sorted)-len(economic))
+	return accumulate(LargestFirst, economic, params, decision)
+}
+
+// selectBranchAndBound search for input set which needs no change output,
+// fallback to largest first if not found like bitcoin core does.
+func selectBranchAndBound(candidates []*Candidate, params *Params) *Result {
+	if result := searchBranchAndBound(candidates, params); result != nil {
+		return result
+	}
+	result := selectLargestFirst(candidates, params)
+	if result != nil {
+		result.Decision = "no input set without change output, fallback to " + result.Decision
+	}
+	return result
+}
+
+// searchBranchAndBound search for input set which needs no change output,
+// return nil if not found.
+// ref. https://github.com/bitcoin/bitcoin/blob/master/src/wallet/coinselection.cpp
+func searchBranchAndBound(candidates []*Candidate, params *Params) *Result {
+	sorted := sortByValueDesc(candidates)
+	pool := make([]*Candidate, 0, len(sorted))
+	values := make([]int64, 0, len(sorted))
+	var available int64
+	for _, c := range sorted {
+		ev := effectiveValue(c, params)
+		if ev <= 0 {
+			continue
+		}
+		pool = append(pool, c)
+		values = append(values, ev)
+		available += ev
+	}
+
+	// effective values already include the input fees,
+	// need is what left after minimum fee and minimum total is considered
+	need := func(count int) int64 {
+		return int64(params.RequiredTotal(count)) - int64(count)*int64(params.InputFee)
+	}
+	if len(pool) == 0 || available < need(len(pool)) {
+		return nil
+	}
+
+	var (
+		best       []bool
+		bestExcess int64 = -1
+		tries      int
+		current    = make([]bool, len(pool))
+		search     func(i, count int, sum, remaining int64)
+	)
+
+	search = func(i, count int, sum, remaining int64) {
+		if tries >= bnbMaxTries || bestExcess == 0 {
+			return
+		}
+		tries++
+		if sum >= need(count) {
+			excess := sum - need(count)
+			if excess <= int64(params.CostOfChange) && (bestExcess < 0 || excess < bestExcess) {
+				bestExcess = excess
+				best = make([]bool, len(current))
+				copy(best, current)
+			}
+			return
+		}
+		if i == len(pool) || sum+remaining < need(count+len(pool)-i) {
+			return
+		}
+		// include the i-th candidate first, then exclude it
+		current[i] = true
+		search(i+1, count+1, sum+values[i], remaining-values[i])
+		current[i] = false
+		search(i+1, count, sum, remaining-values[i])
+	}
+
+	search(0, 0, 0, available)
+
+	if best == nil {
+		return nil
+	}
+	var selected []*Candidate
+	for i, used := range best {
+		if used {
+			selected = append(selected, pool[i])
+		}
+	}
+	return newResult(BranchAndBound, selected, params, "found input set without change output")
+}
+
+// selectConsolidate cover the target with largest utxos first,
+// then add small utxos (smallest first) to consolidate them at low fees.
+func selectConsolidate(candidates []*Candidate, params *Params) *Result {
+	result := selectLargestFirst(candidates, params)
+	if result == nil {
+		return nil
+	}
+	maxInputs := params.MaxInputs
+	if maxInputs <= 0 {
+		result.Strategy = Consolidate
+		return result
+	}
+
+	used := make(map[int]struct{}, len(result.Selected))
+	for _, c := range result.Selected {
+		used[c.Index] = struct{}{}
+	}
+	sorted := sortByValueDesc(candidates)
+	selected := result.Selected
+	added := 0
+	for i := len(sorted) - 1; i >= 0 && len(selected) < maxInputs; i-- {
+		c := sorted[i]
+		if _, exist := used[c.Index]; exist {
+			continue
+		}
+		if effectiveValue(c, params) <= 0 {
+			continue
+		}
+		if params.DustThreshold > 0 && c.Value > params.DustThreshold {
+			break
+		}
+		selected = append(selected, c)
+		added++
+	}
+	decision := fmt.Sprintf("take largest utxos first, then consolidate %v small utxos", added)
+	return newResult(Consolidate, selected, params, decision)
+}
+
+// selectAuto choose strategy according to the current fee rate
+func selectAuto(candidates []*Candidate, params *Params) (result *Result) {
+	switch {
+	case params.IsLowFee():
+		result = selectConsolidate(candidates, params)
+	case params.IsHighFee():
+		result = searchBranchAndBound(candidates, params)
+		if result == nil {
+			result = selectLargestFirst(candidates, params)
+		}
+	default:
+		result = searchBranchAndBound(candidates, params)
+		if result == nil {
+			result = selectGreedy(candidates, params)
+		}
+	}
+	if result != nil {
+		result.Decision = fmt.Sprintf("auto (feeRate %v, low %v, high %v): %v",
+			params.FeeRatePerKb, params.LowFeeRate, params.HighFeeRate, result.Decision)
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package coinselect
+
+import (
+	"testing"
+)
+
+func newCandidates(values ...uint64) []*Candidate {
+	candidates := make([]*Candidate, len(values))
+	for i, value := range values {
+		candidates[i] = &Candidate{Index: i, Value: value}
+	}
+	return candidates
+}
+
+func selectedIndexes(result *Result) map[int]bool {
+	indexes := make(map[int]bool)
+	for _, c := range result.Selected {
+		indexes[c.Index] = true
+	}
+	return indexes
+}
+
+func TestSelectGreedy(t *testing.T) {
+	params := &Params{Amount: 1000, BaseFee: 100, InputFee: 50}
+	result, err := Select(Greedy, newCandidates(500, 400, 300, 200), params)
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	// 500+400+300+200 >= 1000+100+4*50
+	if len(result.Selected) != 4 || result.Total != 1400 || result.Required != 1300 {
+		t.Fatalf("wrong greedy result: %v", result)
+	}
+}
+
+func TestSelectBranchAndBound(t *testing.T) {
+	params := &Params{Amount: 1000, BaseFee: 100, InputFee: 50, CostOfChange: 10}
+	// 700+500 = 1200 exactly covers 1000+100+2*50
+	result, err := Select(BranchAndBound, newCandidates(900, 700, 600, 500), params)
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	indexes := selectedIndexes(result)
+	if len(indexes) != 2 || !indexes[1] || !indexes[3] {
+		t.Fatalf("wrong bnb result: %v", result)
+	}
+	if result.Excess() != 0 {
+		t.Fatalf("bnb result has excess: %v", result)
+	}
+
+	// no exact match, fallback to largest first
+	result, err = Select(BranchAndBound, newCandidates(3000, 5000), params)
+	if err != nil {
+		t.Fatalf("bnb should fallback without exact match: %v", err)
+	}
+	if result.Strategy != LargestFirst || len(result.Selected) != 1 || result.Selected[0].Index != 1 {
+		t.Fatalf("wrong bnb fallback result: %v", result)
+	}
+
+	// not enough balance
+	_, err = Select(BranchAndBound, newCandidates(300, 200), params)
+	if err == nil {
+		t.Fatal("bnb should fail without enough balance")
+	}
+}
+
+func TestSelectLargestFirst(t *testing.T) {
+	params := &Params{Amount: 1000, BaseFee: 100, InputFee: 50}
+	result, err := Select(LargestFirst, newCandidates(30, 400, 900, 40), params)
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	indexes := selectedIndexes(result)
+	if len(indexes) != 2 || !indexes[1] || !indexes[2] {
+		t.Fatalf("wrong largest first result: %v", result)
+	}
+
+	// uneconomic utxos are skipped
+	_, err = Select(LargestFirst, newCandidates(30, 40, 1150), params)
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	_, err = Select(LargestFirst, newCandidates(30, 40, 1100), params)
+	if err == nil {
+		t.Fatal("largest first should not use uneconomic utxos")
+	}
+}
+
+func TestSelectConsolidate(t *testing.T) {
+	params := &Params{Amount: 1000, BaseFee: 100, InputFee: 50, MaxInputs: 3, DustThreshold: 500}
+	result, err := Select(Consolidate, newCandidates(2000, 60, 300, 800, 20), params)
+	if err != nil {
+		t.Fatalf("select failed: %v", err)
+	}
+	indexes := selectedIndexes(result)
+	if len(indexes) != 3 || !indexes[0] || !indexes[1] || !indexes[2] {
+		t.Fatalf("wrong consolidate result: %v", result)
+	}
+	if result.Total < result.Required {
+		t.Fatalf("consolidate result does not cover required: %v", result)
+	}
+}
+
+func TestSelectAuto(t *testing.T) {
+	candidates := newCandidates(900, 700, 600, 500, 60)
+	params := &Params{Amount: 1000, BaseFee: 100, InputFee: 50, CostOfChange: 10, LowFeeRate: 2000, HighFeeRate: 50000}
+
+	params.FeeRatePerKb = 1000
+	result, err := Select(Auto, candidates, params)
+	if err != nil || result.Strategy != Consolidate {
+		t.Fatalf("auto should consolidate at low fee: %v %v", result, err)
+	}
+
+	params.FeeRatePerKb = 100000
+	result, err = Select(Auto, candidates, params)
+	if err != nil || result.Strategy != BranchAndBound {
+		t.Fatalf("auto should use bnb at high fee: %v %v", result, err)
+	}
+}
+
+func TestUnsupportedStrategy(t *testing.T) {
+	if IsSupported("unknown") {
+		t.Fatal("unknown strategy should not be supported")
+	}
+	if !IsSupported("") || !IsSupported("BnB") {
+		t.Fatal("default and case insensitive strategy should be supported")
+	}
+}
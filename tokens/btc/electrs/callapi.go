@@ -48,6 +48,17 @@ func GetTransactionByHash(b tokens.CrossChainBridge, txHash string) (*ElectTx, e
 	return nil, err
 }
 
+// GetTransactionByHashOf call /tx/{txHash} of specified api address
+func GetTransactionByHashOf(apiAddress, txHash string) (*ElectTx, error) {
+	var result ElectTx
+	url := apiAddress + "/tx/" + txHash
+	err := client.RPCGet(&result, url)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetElectTransactionStatus call /tx/{txHash}/status
 func GetElectTransactionStatus(b tokens.CrossChainBridge, txHash string) (*ElectTxStatus, error) {
 	gateway := b.GetGatewayConfig()
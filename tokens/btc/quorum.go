@@ -0,0 +1,23 @@
+package btc
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+)
+
+// get tx used in verification, if quorum mode is enabled,
+// stable tx must be identical across at least quorum gateways.
+func (b *Bridge) getVerifyTransaction(txHash string, allowUnstable bool) (*electrs.ElectTx, error) {
+	gateway := b.GatewayConfig
+	if allowUnstable || !gateway.IsQuorumMode() {
+		return b.GetTransactionByHash(txHash)
+	}
+	result, err := tokens.QuorumCall(gateway.GetQuorumAPIAddress(), gateway.Quorum, "GetTransactionByHash",
+		func(url string) (interface{}, error) {
+			return electrs.GetTransactionByHashOf(url, txHash)
+		}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*electrs.ElectTx), nil
+}
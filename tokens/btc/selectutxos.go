@@ -0,0 +1,123 @@
+package btc
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/coinselect"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+	"github.com/btcsuite/btcwallet/wallet/txauthor"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/btcsuite/btcwallet/wallet/txsizes"
+)
+
+type utxoSelector struct {
+	reserver      string
+	strategy      string
+	outputs       []*wireTxOutType
+	relayFeePerKb btcAmountType
+}
+
+func (s *utxoSelector) isGreedy() bool {
+	return s.strategy == "" || s.strategy == coinselect.Greedy
+}
+
+func (s *utxoSelector) getParams(target btcAmountType) *coinselect.Params {
+	estimateFee := func(inputCount int) uint64 {
+		size := txsizes.EstimateSerializeSize(inputCount, s.outputs, true)
+		return uint64(txrules.FeeForSerializeSize(s.relayFeePerKb, size))
+	}
+	// change under dust threshold is dropped in `NewUnsignedTransaction`
+	changeDust := txrules.GetDustThreshold(txsizes.P2PKHPkScriptSize, txrules.DefaultRelayFeePerKb)
+	return &coinselect.Params{
+		Amount:        uint64(txauthor.SumOutputValues(s.outputs)),
+		InputFee:      uint64(txrules.FeeForSerializeSize(s.relayFeePerKb, txsizes.RedeemP2PKHInputSize)),
+		MinFee:        uint64(cfgMinRelayFee),
+		MinTotal:      uint64(target),
+		CostOfChange:  uint64(changeDust) - 1,
+		FeeRatePerKb:  int64(s.relayFeePerKb),
+		HighFeeRate:   cfgHighFeeRatePerKb,
+		LowFeeRate:    cfgLowFeeRatePerKb,
+		MaxInputs:     cfgConsolidateMaxInputs,
+		DustThreshold: cfgConsolidateMaxValue,
+		EstimateFee:   estimateFee,
+	}
+}
+
+func (b *Bridge) isSpendableUtxo(from string, utxo *electrs.ElectUtxo) bool {
+	tx, err := b.getTransactionByHashWithRetry(*utxo.Txid)
+	if err != nil {
+		return false
+	}
+	if *utxo.Vout >= uint32(len(tx.Vout)) {
+		return false
+	}
+	output := tx.Vout[*utxo.Vout]
+	if *output.ScriptpubkeyType != p2pkhType {
+		return false
+	}
+	if output.ScriptpubkeyAddress == nil || *output.ScriptpubkeyAddress != from {
+		return false
+	}
+	return true
+}
+
+func (b *Bridge) selectUtxosWithStrategy(from string, target btcAmountType, selector *utxoSelector, utxos []*electrs.ElectUtxo, p2pkhScript []byte) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
+	var candidates []*coinselect.Candidate
+	for i, utxo := range utxos {
+		value := btcAmountType(*utxo.Value)
+		if !isValidValue(value) {
+			continue
+		}
+		if tools.IsUtxoReservedByOthers(*utxo.Txid, *utxo.Vout, selector.reserver) {
+			continue
+		}
+		candidates = append(candidates, &coinselect.Candidate{Index: i, Value: uint64(value)})
+	}
+
+	params := selector.getParams(target)
+	checked := make(map[int]bool)
+	for {
+		result, errs := coinselect.Select(selector.strategy, candidates, params)
+		if errs != nil {
+			return 0, nil, nil, nil, errs
+		}
+
+		// check selected utxos lazily, and select again if any is not spendable
+		invalids := make(map[int]struct{})
+		for _, c := range result.Selected {
+			valid, exist := checked[c.Index]
+			if !exist {
+				valid = b.isSpendableUtxo(from, utxos[c.Index])
+				checked[c.Index] = valid
+			}
+			if !valid {
+				invalids[c.Index] = struct{}{}
+			}
+		}
+		if len(invalids) > 0 {
+			remains := make([]*coinselect.Candidate, 0, len(candidates)-len(invalids))
+			for _, c := range candidates {
+				if _, exist := invalids[c.Index]; !exist {
+					remains = append(remains, c)
+				}
+			}
+			candidates = remains
+			continue
+		}
+
+		for _, c := range result.Selected {
+			utxo := utxos[c.Index]
+			txIn, errf := b.NewTxIn(*utxo.Txid, *utxo.Vout, p2pkhScript)
+			if errf != nil {
+				return 0, nil, nil, nil, errf
+			}
+			value := btcAmountType(c.Value)
+			total += value
+			inputs = append(inputs, txIn)
+			inputValues = append(inputValues, value)
+			scripts = append(scripts, p2pkhScript)
+		}
+		log.Info(b.ChainConfig.BlockChain+" select utxos", "from", from, "reserver", selector.reserver, "target", target, "candidates", len(candidates), "result", result.String())
+		return total, inputs, inputValues, scripts, nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 )
 
@@ -29,5 +30,9 @@ func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error
 	txHex := hex.EncodeToString(buf.Bytes())
 	log.Info("Bridge send tx", "hash", tx.TxHash())
 
-	return b.PostTransaction(txHex)
+	txHash, err = b.PostTransaction(txHex)
+	if err == nil {
+		tools.SetUtxosSpendTx(getTxInOutPoints(tx.TxIn), txHash)
+	}
+	return txHash, err
 }
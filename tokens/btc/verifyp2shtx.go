@@ -30,7 +30,7 @@ func (b *Bridge) verifyP2shSwapinTx(pairID, txHash, bindAddress string, allowUns
 	if !allowUnstable && !b.checkStable(txHash) {
 		return swapInfo, tokens.ErrTxNotStable
 	}
-	tx, err := b.GetTransactionByHash(txHash)
+	tx, err := b.getVerifyTransaction(txHash, allowUnstable)
 	if err != nil {
 		log.Debug("[verifyP2sh] "+b.ChainConfig.BlockChain+" Bridge::GetTransaction fail", "tx", txHash, "err", err)
 		return swapInfo, tokens.ErrTxNotFound
@@ -10,6 +10,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/giangnamnabka/btcwallet/wallet/txauthor"
 	"github.com/giangnamnabka/btcwallet/wallet/txrules"
 	"github.com/giangnamnabka/btcwallet/wallet/txsizes"
@@ -51,7 +52,7 @@ func (b *Bridge) getRelayFeePerKb() (estimateFee int64, err error) {
 func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	var (
 		pairID        = args.PairID
-		token         = b.GetTokenConfig(pairID)
+		token         = args.GetTokenConfig(b.IsSrcEndpoint())
 		from          string
 		to            string
 		changeAddress string
@@ -72,7 +73,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		to = args.Bind                    // to
 		changeAddress = token.DcrmAddress // change
 
-		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false, args.OriginFrom, args.OriginTxTo) // amount
+		amount = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, args.OriginFrom, args.OriginTxTo, args.TokenPrice) // amount
 		memo = tokens.UnlockMemoPrefix + args.SwapID
 	default:
 		return nil, tokens.ErrUnknownSwapType
@@ -109,11 +110,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	reserver := tools.GetUtxoReserver(args)
+	needReserve := len(extra.PreviousOutPoints) == 0
+
 	inputSource := func(target colxAmountType) (total colxAmountType, inputs []*wireTxInType, inputValues []colxAmountType, scripts [][]byte, err error) {
 		if len(extra.PreviousOutPoints) != 0 {
 			return b.getUtxos(from, target, extra.PreviousOutPoints)
 		}
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, reserver)
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -127,6 +131,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 
 	updateExtraInfo(extra, authoredTx.Tx.TxIn)
 
+	if needReserve {
+		err = tools.ReserveUtxos(reserver, from, extra.PreviousOutPoints)
+		if err != nil {
+			extra.PreviousOutPoints = nil
+			return nil, err
+		}
+	}
+
 	if args.SwapType != tokens.NoSwapType {
 		args.Identifier = params.GetIdentifier()
 	}
@@ -138,14 +150,19 @@ func updateExtraInfo(extra *tokens.BtcExtraArgs, txins []*wireTxInType) {
 	if len(extra.PreviousOutPoints) > 0 {
 		return
 	}
-	extra.PreviousOutPoints = make([]*tokens.BtcOutPoint, len(txins))
+	extra.PreviousOutPoints = getTxInOutPoints(txins)
+}
+
+func getTxInOutPoints(txins []*wireTxInType) []*tokens.BtcOutPoint {
+	points := make([]*tokens.BtcOutPoint, len(txins))
 	for i, txin := range txins {
 		point := txin.PreviousOutPoint
-		extra.PreviousOutPoints[i] = &tokens.BtcOutPoint{
+		points[i] = &tokens.BtcOutPoint{
 			Hash:  point.Hash.String(),
 			Index: point.Index,
 		}
 	}
+	return points
 }
 
 // BuildTransaction build tx
@@ -169,7 +186,7 @@ func (b *Bridge) BuildTransaction(from string, receivers []string, amounts []int
 	}
 
 	inputSource := func(target colxAmountType) (total colxAmountType, inputs []*wireTxInType, inputValues []colxAmountType, scripts [][]byte, err error) {
-		return b.selectUtxos(from, target)
+		return b.selectUtxos(from, target, "")
 	}
 
 	changeSource := func() ([]byte, error) {
@@ -254,7 +271,7 @@ func (b *Bridge) getOutspendWithRetry(point *tokens.BtcOutPoint) (outspend *elec
 	return outspend, err
 }
 
-func (b *Bridge) selectUtxos(from string, target colxAmountType) (total colxAmountType, inputs []*wireTxInType, inputValues []colxAmountType, scripts [][]byte, err error) {
+func (b *Bridge) selectUtxos(from string, target colxAmountType, reserver string) (total colxAmountType, inputs []*wireTxInType, inputValues []colxAmountType, scripts [][]byte, err error) {
 	p2pkhScript, err := b.GetPayToAddrScript(from)
 	if err != nil {
 		return 0, nil, nil, nil, err
@@ -271,13 +288,13 @@ func (b *Bridge) selectUtxos(from string, target colxAmountType) (total colxAmou
 	)
 
 	for _, utxo := range utxos {
-		if b.IsUtxoLocked(*utxo.Txid, int(*utxo.Vout)) {
-			continue
-		}
 		value := colxAmountType(*utxo.Value)
 		if !isValidValue(value) {
 			continue
 		}
+		if tools.IsUtxoReservedByOthers(*utxo.Txid, *utxo.Vout, reserver) {
+			continue
+		}
 		tx, err = b.getTransactionByHashWithRetry(*utxo.Txid)
 		if err != nil {
 			continue
@@ -442,13 +459,6 @@ func (b *Bridge) NewUnsignedTransaction(outputs []*wireTxOutType, relayFeePerKb
 			}
 		}
 
-		// Add inputs to lockedUtxos so that other transactions wont reuse them
-		for _, txin := range unsignedTransaction.TxIn {
-			inputtxhash := txin.PreviousOutPoint.Hash.String()
-			inputvout := int(txin.PreviousOutPoint.Index)
-			_ = b.LockUtxo(inputtxhash, inputvout)
-		}
-
 		return &txauthor.AuthoredTx{
 			Tx:              unsignedTransaction,
 			PrevScripts:     scripts,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 	"github.com/giangnamnabka/btcwallet/wallet/txauthor"
 )
 
@@ -31,18 +32,7 @@ func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error
 
 	txHash, err = b.PostTransaction(txHex)
 	if err == nil {
-		for _, txin := range tx.TxIn {
-			inputtxhash := txin.PreviousOutPoint.Hash.String()
-			inputvout := int(txin.PreviousOutPoint.Index)
-			cond := func() bool {
-				status, getstatuserr := b.GetElectTransactionStatus(txHash)
-				if getstatuserr == nil && *status.Confirmed {
-					return true
-				}
-				return false
-			}
-			_ = b.SetUnlockUtxoCond(inputtxhash, inputvout, cond)
-		}
+		tools.SetUtxosSpendTx(getTxInOutPoints(tx.TxIn), txHash)
 	}
 	return txHash, err
 }
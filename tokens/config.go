@@ -4,8 +4,10 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
+	"sync/atomic"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
@@ -23,13 +25,62 @@ type BtcExtraConfig struct {
 	UtxoAggregateMinCount  int
 	UtxoAggregateMinValue  uint64
 	UtxoAggregateToAddress string
+
+	CoinSelection        string // greedy (default), bnb, largestfirst, consolidate, auto
+	HighFeeRatePerKb     int64
+	LowFeeRatePerKb      int64
+	ConsolidateMaxInputs int
+	ConsolidateMaxValue  uint64
 }
 
 // GatewayConfig struct
 type GatewayConfig struct {
 	APIAddress    []string
 	APIAddressExt []string
-	Extras        *GatewayExtras `json:",omitempty"`
+	Extras        *GatewayExtras            `json:",omitempty"`
+	HealthCheck   *GatewayHealthCheckConfig `json:",omitempty"`
+	Quorum        uint64                    `json:",omitempty"` // K of N gateways must agree in verification
+
+	// cached values
+	quorumAPIAddress []string
+}
+
+// InitQuorum init quorum api addresses (all of APIAddress and APIAddressExt)
+func (c *GatewayConfig) InitQuorum() error {
+	exist := make(map[string]struct{})
+	c.quorumAPIAddress = nil
+	for _, apiAddresses := range [][]string{c.APIAddress, c.APIAddressExt} {
+		for _, apiAddress := range apiAddresses {
+			if _, ok := exist[apiAddress]; ok {
+				continue
+			}
+			exist[apiAddress] = struct{}{}
+			c.quorumAPIAddress = append(c.quorumAPIAddress, apiAddress)
+		}
+	}
+	if c.Quorum > uint64(len(c.quorumAPIAddress)) {
+		return fmt.Errorf("gateway 'Quorum' %v is larger than the count of gateways %v", c.Quorum, len(c.quorumAPIAddress))
+	}
+	return nil
+}
+
+// IsQuorumMode is quorum reads enabled in verification
+func (c *GatewayConfig) IsQuorumMode() bool {
+	return c.Quorum > 1
+}
+
+// GetQuorumAPIAddress get api addresses used in quorum reads
+func (c *GatewayConfig) GetQuorumAPIAddress() []string {
+	return c.quorumAPIAddress
+}
+
+// GatewayHealthCheckConfig gateway health check config
+type GatewayHealthCheckConfig struct {
+	MaxHeightLag   uint64  // blocks
+	MaxLatency     uint64  // milliseconds
+	MaxErrorRate   float64 // in recent probes
+	InitialBackoff uint64  // seconds
+	MaxBackoff     uint64  // seconds
 }
 
 // GatewayExtras struct
@@ -93,7 +144,15 @@ type ChainConfig struct {
 	MaxGasTipCap         string
 	MaxGasFeeCap         string
 
+	// gas strategy: "" or median (median of eth_gasPrice), percentile, inclusiontime, timeofday
+	GasStrategy          string
+	GasPercentile        float64            `json:",omitempty"` // reward percentile of fee history
+	GasTargetBlocks      uint64             `json:",omitempty"` // expected blocks to be included
+	GasBudgets           []*GasBudgetConfig `json:",omitempty"` // budgets by UTC hours of day
+	GasStrategyTolerance float64            `json:",omitempty"` // max relative difference in accepting sign
+
 	// cached values
+	nativePrice   uint64 // bits of float64, reloaded concurrently
 	chainID       *big.Int
 	fixedGasPrice *big.Int
 	maxGasPrice   *big.Int
@@ -106,10 +165,22 @@ type ChainConfig struct {
 	callByContractCodeHashWhitelist map[string]struct{}
 }
 
+// GasBudgetConfig gas budget of UTC hours in range [FromHour, ToHour)
+type GasBudgetConfig struct {
+	FromHour    int
+	ToHour      int
+	Percentile  float64
+	MaxGasPrice string
+
+	maxGasPrice *big.Int
+}
+
 // TokenPriceConfig struct
 type TokenPriceConfig struct {
-	Contract   string
-	APIAddress []string
+	Contract       string
+	APIAddress     []string
+	ReloadInterval uint64  // seconds, reload token prices periodically if not zero
+	Tolerance      float64 // max relative difference of token (or native) price in accepting sign
 }
 
 // TokenConfig struct
@@ -130,8 +201,14 @@ type TokenConfig struct {
 	SwapFeeRate            *float64
 	MaximumSwapFee         *float64
 	MinimumSwapFee         *float64
-	TokenPrice             float64 `toml:"-"`
-	PlusGasPricePercentage uint64  `json:",omitempty"`
+	MaximumSwapUSD         *float64 `json:",omitempty"` // USD denominated (converted with token price)
+	MinimumSwapUSD         *float64 `json:",omitempty"`
+	BigValueThresholdUSD   *float64 `json:",omitempty"`
+	MaximumSwapFeeUSD      *float64 `json:",omitempty"`
+	MinimumSwapFeeUSD      *float64 `json:",omitempty"`
+	SwapFeeMode            string   `json:",omitempty"` // "" (default) or "gascost"
+	TokenPrice             float64  `toml:"-"`
+	PlusGasPricePercentage uint64   `json:",omitempty"`
 	DisableSwap            bool
 	IsDelegateContract     bool
 	DelegateToken          string `json:",omitempty"`
@@ -147,6 +224,9 @@ type TokenConfig struct {
 	// use private key address instead
 	DcrmAddressPriKey string `json:"-"`
 
+	// additional sender accounts to swap in parallel (eth like only)
+	DcrmAddressPool []*SenderAccountConfig `json:",omitempty"`
+
 	// calced value
 	maxSwap          *big.Int
 	minSwap          *big.Int
@@ -158,10 +238,20 @@ type TokenConfig struct {
 	RippleExtra       *RippleTokenExtra
 }
 
+// SenderAccountConfig sender account (dcrm address or sub account) config
+type SenderAccountConfig struct {
+	Address string
+	Pubkey  string `json:"-"`
+	PriKey  string `json:"-"`
+}
+
 // RippleTokenExtra ripple extra
 type RippleTokenExtra struct {
 	Currency string
 	Issuer   string
+
+	// max trust limit of dcrm address, oracles refuse to sign trust set exceeding it
+	TrustLimit string `json:",omitempty"`
 }
 
 // IsNative is native of ripple
@@ -250,6 +340,10 @@ func (c *ChainConfig) CheckConfig(isServer bool) error {
 			c.callByContractCodeHashWhitelist[codehash] = struct{}{}
 		}
 	}
+	err := c.checkGasStrategy()
+	if err != nil {
+		return err
+	}
 	if c.EnableDynamicFeeTx {
 		if c.MaxGasTipCap != "" {
 			bi, err := common.GetBigIntFromStr(c.MaxGasTipCap)
@@ -343,6 +437,19 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 	if c.BigValueThreshold == nil {
 		return errors.New("token must config 'BigValueThreshold'")
 	}
+	err = c.checkUSDSettings()
+	if err != nil {
+		return err
+	}
+	switch c.SwapFeeMode {
+	case "":
+	case SwapFeeModeGasCost:
+		if TokenPriceCfg == nil {
+			return errors.New("must config 'TokenPrice' if 'SwapFeeMode' is gascost")
+		}
+	default:
+		return fmt.Errorf("unknown 'SwapFeeMode' %v", c.SwapFeeMode)
+	}
 	if c.DcrmAddress == "" {
 		return errors.New("token must config 'DcrmAddress'")
 	}
@@ -393,6 +500,10 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 	if err != nil {
 		return err
 	}
+	err = c.checkDcrmAddressPool()
+	if err != nil {
+		return err
+	}
 	if TokenPriceCfg == nil {
 		c.CalcAndStoreValue()
 	}
@@ -416,39 +527,112 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 	return nil
 }
 
-// CalcAndStoreValue calc and store value (minus duplicate calculation)
-func (c *TokenConfig) CalcAndStoreValue() {
-	maxSwap := *c.MaximumSwap
-	minSwap := *c.MinimumSwap
-	bigSwap := *c.BigValueThreshold
-	maxFee := *c.MaximumSwapFee
-	minFee := *c.MinimumSwapFee
-	if c.TokenPrice > 0 {
-		// convert to token amount
-		maxSwap /= c.TokenPrice
-		minSwap /= c.TokenPrice
-		bigSwap /= c.TokenPrice
-		maxFee /= c.TokenPrice
-		minFee /= c.TokenPrice
+func (c *TokenConfig) checkUSDSettings() error {
+	if !c.HasUSDSettings() {
+		return nil
 	}
+	if TokenPriceCfg == nil {
+		return errors.New("must config 'TokenPrice' if use USD denominated settings")
+	}
+	for _, v := range []*float64{c.MaximumSwapUSD, c.MinimumSwapUSD, c.BigValueThresholdUSD, c.MaximumSwapFeeUSD, c.MinimumSwapFeeUSD} {
+		if v != nil && *v < 0 {
+			return errors.New("USD denominated settings must be non-negative")
+		}
+	}
+	if c.MinimumSwapUSD != nil && c.MaximumSwapUSD != nil && *c.MinimumSwapUSD > *c.MaximumSwapUSD {
+		return errors.New("wrong token config, MinimumSwapUSD > MaximumSwapUSD")
+	}
+	if c.MinimumSwapFeeUSD != nil && c.MaximumSwapFeeUSD != nil && *c.MinimumSwapFeeUSD > *c.MaximumSwapFeeUSD {
+		return errors.New("wrong token config, MinimumSwapFeeUSD > MaximumSwapFeeUSD")
+	}
+	return nil
+}
+
+// HasUSDSettings has any USD denominated settings
+func (c *TokenConfig) HasUSDSettings() bool {
+	return c.MaximumSwapUSD != nil ||
+		c.MinimumSwapUSD != nil ||
+		c.BigValueThresholdUSD != nil ||
+		c.MaximumSwapFeeUSD != nil ||
+		c.MinimumSwapFeeUSD != nil
+}
+
+// swapValues swap and fee values in token's smallest unit
+type swapValues struct {
+	maxSwap          *big.Int
+	minSwap          *big.Int
+	maxSwapFee       *big.Int
+	minSwapFee       *big.Int
+	bigValThreshhold *big.Int
+}
+
+// calcSwapValues calc swap and fee values with the specified token price
+func (c *TokenConfig) calcSwapValues(tokenPrice float64) *swapValues {
+	hasUSDSettings := c.HasUSDSettings()
+	convert := func(value, valueUSD *float64) float64 {
+		if tokenPrice > 0 {
+			if valueUSD != nil {
+				return *valueUSD / tokenPrice
+			}
+			if !hasUSDSettings {
+				// compatible with old config, all are USD denominated
+				return *value / tokenPrice
+			}
+		}
+		return *value
+	}
+	maxSwap := convert(c.MaximumSwap, c.MaximumSwapUSD)
+	minSwap := convert(c.MinimumSwap, c.MinimumSwapUSD)
+	bigSwap := convert(c.BigValueThreshold, c.BigValueThresholdUSD)
+	maxFee := convert(c.MaximumSwapFee, c.MaximumSwapFeeUSD)
+	minFee := convert(c.MinimumSwapFee, c.MinimumSwapFeeUSD)
+
 	smallBiasValue := 0.0001
 	decimals := *c.Decimals
-	c.maxSwap = ToBits(maxSwap+smallBiasValue, decimals)
-	c.minSwap = ToBits(minSwap-smallBiasValue, decimals)
-	c.maxSwapFee = ToBits(maxFee, decimals)
-	c.minSwapFee = ToBits(minFee, decimals)
-	c.bigValThreshhold = ToBits(bigSwap+smallBiasValue, decimals)
+	v := &swapValues{
+		maxSwap:          ToBits(maxSwap+smallBiasValue, decimals),
+		minSwap:          ToBits(minSwap-smallBiasValue, decimals),
+		maxSwapFee:       ToBits(maxFee, decimals),
+		minSwapFee:       ToBits(minFee, decimals),
+		bigValThreshhold: ToBits(bigSwap+smallBiasValue, decimals),
+	}
 	if decimals > 8 {
 		mod := big.NewInt(10)
 		mod.Exp(mod, big.NewInt(int64(decimals-8)), nil)
-		c.maxSwap = calcModValue(c.maxSwap, mod)
-		c.minSwap = calcModValue(c.minSwap, mod)
-		c.maxSwapFee = calcModValue(c.maxSwapFee, mod)
-		c.minSwapFee = calcModValue(c.minSwapFee, mod)
-		c.bigValThreshhold = calcModValue(c.bigValThreshhold, mod)
+		v.maxSwap = calcModValue(v.maxSwap, mod)
+		v.minSwap = calcModValue(v.minSwap, mod)
+		v.maxSwapFee = calcModValue(v.maxSwapFee, mod)
+		v.minSwapFee = calcModValue(v.minSwapFee, mod)
+		v.bigValThreshhold = calcModValue(v.bigValThreshhold, mod)
 	}
+	return v
+}
+
+// getSwapValues get stored swap values if token price is zero or not changed,
+// otherwise calc swap values with the specified token price.
+func (c *TokenConfig) getSwapValues(tokenPrice float64) *swapValues {
+	if tokenPrice > 0 && tokenPrice != c.TokenPrice {
+		return c.calcSwapValues(tokenPrice)
+	}
+	return &swapValues{
+		maxSwap:          c.maxSwap,
+		minSwap:          c.minSwap,
+		maxSwapFee:       c.maxSwapFee,
+		minSwapFee:       c.minSwapFee,
+		bigValThreshhold: c.bigValThreshhold,
+	}
+}
+
+// CalcAndStoreValue calc and store value (minus duplicate calculation)
+func (c *TokenConfig) CalcAndStoreValue() {
+	v := c.calcSwapValues(c.TokenPrice)
+	c.maxSwap = v.maxSwap
+	c.minSwap = v.minSwap
+	c.maxSwapFee = v.maxSwapFee
+	c.minSwapFee = v.minSwapFee
+	c.bigValThreshhold = v.bigValThreshhold
 	log.Info("calc and store token swap and fee success",
-		"name", c.Name, "decimals", decimals, "contractAddress", c.ContractAddress,
+		"name", c.Name, "decimals", *c.Decimals, "contractAddress", c.ContractAddress, "tokenPrice", c.TokenPrice,
 		"maxSwap", c.maxSwap, "minSwap", c.minSwap, "bigValThreshhold", c.bigValThreshhold,
 		"maxSwapFee", c.maxSwapFee, "minSwapFee", c.minSwapFee, "swapFeeRate", c.SwapFeeRate,
 	)
@@ -466,11 +650,92 @@ func (c *ChainConfig) SetChainID(chainID *big.Int) {
 	c.chainID = chainID
 }
 
+// GetMaxHeightLag get max height lag
+func (c *GatewayHealthCheckConfig) GetMaxHeightLag() uint64 {
+	if c == nil || c.MaxHeightLag == 0 {
+		return 10
+	}
+	return c.MaxHeightLag
+}
+
+// GetMaxLatency get max latency in milliseconds
+func (c *GatewayHealthCheckConfig) GetMaxLatency() uint64 {
+	if c == nil || c.MaxLatency == 0 {
+		return 5000
+	}
+	return c.MaxLatency
+}
+
+// GetMaxErrorRate get max error rate
+func (c *GatewayHealthCheckConfig) GetMaxErrorRate() float64 {
+	if c == nil || c.MaxErrorRate == 0 {
+		return 0.5
+	}
+	return c.MaxErrorRate
+}
+
+// GetInitialBackoff get initial backoff in seconds
+func (c *GatewayHealthCheckConfig) GetInitialBackoff() uint64 {
+	if c == nil || c.InitialBackoff == 0 {
+		return 60
+	}
+	return c.InitialBackoff
+}
+
+// GetMaxBackoff get max backoff in seconds
+func (c *GatewayHealthCheckConfig) GetMaxBackoff() uint64 {
+	if c == nil || c.MaxBackoff == 0 {
+		return 3600
+	}
+	return c.MaxBackoff
+}
+
+// strategy specific checks are done in eth bridge
+func (c *ChainConfig) checkGasStrategy() error {
+	if c.GasPercentile < 0 || c.GasPercentile > 100 {
+		return errors.New("'GasPercentile' must be in range [0, 100]")
+	}
+	if c.GasStrategyTolerance < 0 || c.GasStrategyTolerance >= 1 {
+		return errors.New("'GasStrategyTolerance' must be in range [0, 1)")
+	}
+	for _, budget := range c.GasBudgets {
+		if budget.FromHour < 0 || budget.FromHour > 23 || budget.ToHour < 0 || budget.ToHour > 24 {
+			return fmt.Errorf("wrong hours in 'GasBudgets' [%v, %v)", budget.FromHour, budget.ToHour)
+		}
+		if budget.Percentile < 0 || budget.Percentile > 100 {
+			return errors.New("'Percentile' of 'GasBudgets' must be in range [0, 100]")
+		}
+		if budget.MaxGasPrice != "" {
+			bi, err := common.GetBigIntFromStr(budget.MaxGasPrice)
+			if err != nil {
+				return fmt.Errorf("wrong 'MaxGasPrice' of 'GasBudgets': %w", err)
+			}
+			budget.maxGasPrice = bi
+		}
+	}
+	return nil
+}
+
+// GetMaxGasPrice get max gas price of budget
+func (c *GasBudgetConfig) GetMaxGasPrice() *big.Int {
+	return c.maxGasPrice
+}
+
 // GetChainID get chainID
 func (c *ChainConfig) GetChainID() *big.Int {
 	return c.chainID
 }
 
+// SetNativePrice set native coin price
+func (c *ChainConfig) SetNativePrice(price float64) {
+	atomic.StoreUint64(&c.nativePrice, math.Float64bits(price))
+}
+
+// GetNativePrice get native coin price
+func (c *ChainConfig) GetNativePrice() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&c.nativePrice))
+}
+
 // IsInCallByContractWhitelist is in call by contract whitelist
 func (c *ChainConfig) IsInCallByContractWhitelist(caller string) bool {
 	if c.callByContractWhitelist == nil {
@@ -543,6 +808,11 @@ func (c *TokenConfig) IsErc20() bool {
 	return strings.EqualFold(c.ID, "ERC20") || c.IsProxyErc20()
 }
 
+// IsGasCostFeeMode is swap fee including gas cost on destination chain
+func (c *TokenConfig) IsGasCostFeeMode() bool {
+	return c.SwapFeeMode == SwapFeeModeGasCost
+}
+
 // IsProxyErc20 return if token is proxy contract of erc20
 func (c *TokenConfig) IsProxyErc20() bool {
 	return strings.EqualFold(c.ID, "ProxyERC20")
@@ -582,8 +852,11 @@ func (c *TokenConfig) VerifyDcrmPublicKey() error {
 		return nil
 	}
 
-	// ETH like address
-	pkBytes := common.FromHex(c.DcrmPubkey)
+	return verifyEthPublicKey(c.DcrmAddress, c.DcrmPubkey)
+}
+
+func verifyEthPublicKey(address, pubkey string) error {
+	pkBytes := common.FromHex(pubkey)
 	if len(pkBytes) != 65 || pkBytes[0] != 4 {
 		return fmt.Errorf("wrong uncompressed dcrm public key")
 	}
@@ -593,8 +866,92 @@ func (c *TokenConfig) VerifyDcrmPublicKey() error {
 		Y:     new(big.Int).SetBytes(pkBytes[33:65]),
 	}
 	pubAddr := crypto.PubkeyToAddress(pubKey)
-	if !strings.EqualFold(pubAddr.String(), c.DcrmAddress) {
-		return fmt.Errorf("dcrm address %v and public key address %v is not match", c.DcrmAddress, pubAddr.String())
+	if !strings.EqualFold(pubAddr.String(), address) {
+		return fmt.Errorf("dcrm address %v and public key address %v is not match", address, pubAddr.String())
 	}
 	return nil
 }
+
+func (c *TokenConfig) checkDcrmAddressPool() error {
+	if len(c.DcrmAddressPool) == 0 {
+		return nil
+	}
+	if !common.IsHexAddress(c.DcrmAddress) {
+		return errors.New("'DcrmAddressPool' is only supported for eth like chains")
+	}
+	exist := map[string]struct{}{strings.ToLower(c.DcrmAddress): {}}
+	for _, sender := range c.DcrmAddressPool {
+		if !common.IsHexAddress(sender.Address) {
+			return fmt.Errorf("wrong address '%v' in 'DcrmAddressPool'", sender.Address)
+		}
+		key := strings.ToLower(sender.Address)
+		if _, dup := exist[key]; dup {
+			return fmt.Errorf("duplicate address '%v' in 'DcrmAddressPool'", sender.Address)
+		}
+		exist[key] = struct{}{}
+		if sender.GetPrivateKey() != nil {
+			continue
+		}
+		if IsDcrmDisabled {
+			return fmt.Errorf("dcrm is disabled but no private key is provided for %v", sender.Address)
+		}
+		if err := verifyEthPublicKey(sender.Address, sender.Pubkey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPrivateKey get private key of sender account
+func (s *SenderAccountConfig) GetPrivateKey() *string {
+	if common.HasHexPrefix(s.PriKey) {
+		s.PriKey = s.PriKey[2:]
+	}
+	if s.PriKey == "" {
+		return nil
+	}
+	return &s.PriKey
+}
+
+// GetDcrmAddresses get dcrm address and addresses in pool
+func (c *TokenConfig) GetDcrmAddresses() []string {
+	addresses := make([]string, 0, len(c.DcrmAddressPool)+1)
+	addresses = append(addresses, c.DcrmAddress)
+	for _, sender := range c.DcrmAddressPool {
+		addresses = append(addresses, sender.Address)
+	}
+	return addresses
+}
+
+// IsDcrmAddress is dcrm address or address in pool
+func (c *TokenConfig) IsDcrmAddress(address string) bool {
+	if strings.EqualFold(address, c.DcrmAddress) {
+		return true
+	}
+	return c.getPoolSender(address) != nil
+}
+
+func (c *TokenConfig) getPoolSender(address string) *SenderAccountConfig {
+	for _, sender := range c.DcrmAddressPool {
+		if strings.EqualFold(address, sender.Address) {
+			return sender
+		}
+	}
+	return nil
+}
+
+// GetDcrmPubkeyOf get public key of sender (empty means dcrm address)
+func (c *TokenConfig) GetDcrmPubkeyOf(sender string) string {
+	if poolSender := c.getPoolSender(sender); poolSender != nil {
+		return poolSender.Pubkey
+	}
+	return c.DcrmPubkey
+}
+
+// GetPrivateKeyOf get private key of sender (empty means dcrm address)
+func (c *TokenConfig) GetPrivateKeyOf(sender string) *string {
+	if poolSender := c.getPoolSender(sender); poolSender != nil {
+		return poolSender.GetPrivateKey()
+	}
+	return c.GetDcrmAddressPrivateKey()
+}
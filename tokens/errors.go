@@ -15,6 +15,7 @@ var (
 	ErrWrongRawTx                    = errors.New("wrong raw tx")
 	ErrWrongExtraArgs                = errors.New("wrong extra args")
 	ErrNoBtcBridge                   = errors.New("no btc bridge exist")
+	ErrNoRippleBridge                = errors.New("no ripple bridge exist")
 	ErrWrongSwapinTxType             = errors.New("wrong swapin tx type")
 	ErrBuildSwapTxInWrongEndpoint    = errors.New("build swap in/out tx in wrong endpoint")
 	ErrTxBeforeInitialHeight         = errors.New("transaction before initial block height")
@@ -44,10 +45,17 @@ var (
 	ErrTxWithWrongReceipt   = errors.New("tx with wrong receipt")
 	ErrEstimateGasFailed    = errors.New("estimate gas failed")
 	ErrMissTokenPrice       = errors.New("miss token price")
+	ErrTokenPriceMismatch   = errors.New("token price mismatch")
+	ErrValueOutOfTolerance  = errors.New("value out of tolerance")
+	ErrQuorumNotReached     = errors.New("gateway quorum not reached")
+	ErrGasPriceMismatch     = errors.New("gas price mismatch")
 	ErrTxWithWrongSender    = errors.New("tx with wrong sender")
 	ErrTxWithWrongStatus    = errors.New("tx with wrong status")
 	ErrTxWithNoPayment      = errors.New("tx with no payment")
 	ErrTxIsNotValidated     = errors.New("tx is not validated")
+	ErrTxWouldRevert        = errors.New("tx would revert")
+	ErrRevertReasonNotFound = errors.New("revert reason not found")
+	ErrPairVersionMismatch  = errors.New("pair config version mismatch")
 
 	// errors should register
 	ErrTxWithWrongMemo       = errors.New("tx with wrong memo")
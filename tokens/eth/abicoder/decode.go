@@ -1,7 +1,9 @@
 package abicoder
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -112,11 +114,12 @@ func ParseStringInData(data []byte, pos uint64) (string, error) {
 	if overflow {
 		return "", ErrParseDataError
 	}
-	length, overflow := common.GetUint64(data, offset, 32)
-	if overflow {
+	dataLen := uint64(len(data))
+	if offset > dataLen || dataLen-offset < 32 {
 		return "", ErrParseDataError
 	}
-	if uint64(len(data)) < offset+32+length {
+	length, overflow := common.GetUint64(data, offset, 32)
+	if overflow || dataLen-offset-32 < length {
 		return "", ErrParseDataError
 	}
 	return string(common.GetData(data, offset+32, length)), nil
@@ -158,3 +161,29 @@ func ParseBytesInData(data []byte, pos uint64) (hexutil.Bytes, error) {
 	}
 	return common.GetData(data, offset+32, length), nil
 }
+
+var (
+	// Error(string)
+	revertErrorFuncHash = common.FromHex("0x08c379a0")
+	// Panic(uint256)
+	revertPanicFuncHash = common.FromHex("0x4e487b71")
+)
+
+// ParseRevertReason parse revert reason from revert data
+func ParseRevertReason(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", ErrParseDataError
+	}
+	funcHash, params := data[:4], data[4:]
+	switch {
+	case bytes.Equal(funcHash, revertErrorFuncHash):
+		return ParseStringInData(params, 0)
+	case bytes.Equal(funcHash, revertPanicFuncHash):
+		if len(params) < 32 {
+			return "", ErrParseDataError
+		}
+		return fmt.Sprintf("panic code 0x%x", common.GetBigInt(params, 0, 32)), nil
+	default:
+		return fmt.Sprintf("custom error %v", hexutil.Bytes(data)), nil
+	}
+}
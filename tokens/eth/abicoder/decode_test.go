@@ -0,0 +1,88 @@
+package abicoder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+type revertReasonTest struct {
+	data    string
+	reason  string
+	wantErr error
+}
+
+var revertReasonTests = []revertReasonTest{
+	// Error("insufficient balance")
+	{
+		data: "0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000014" +
+			"696e73756666696369656e742062616c616e6365000000000000000000000000",
+		reason: "insufficient balance",
+	},
+	// Error("")
+	{
+		data: "0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		reason: "",
+	},
+	// Panic(0x11) arithmetic overflow
+	{
+		data: "0x4e487b71" +
+			"0000000000000000000000000000000000000000000000000000000000000011",
+		reason: "panic code 0x11",
+	},
+	// custom error
+	{
+		data:   "0x12345678",
+		reason: "custom error 0x12345678",
+	},
+	// too short
+	{
+		data:    "0x08c379",
+		wantErr: ErrParseDataError,
+	},
+	// error string is truncated
+	{
+		data: "0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000040" +
+			"696e73756666696369656e742062616c616e6365000000000000000000000000",
+		wantErr: ErrParseDataError,
+	},
+	// error string offset out of range
+	{
+		data: "0x08c379a0" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe0" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		wantErr: ErrParseDataError,
+	},
+	// error string offset and length overflow
+	{
+		data: "0x08c379a0" +
+			"000000000000000000000000000000000000000000000000ffffffffffffffe0" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		wantErr: ErrParseDataError,
+	},
+	// panic code is truncated
+	{
+		data:    "0x4e487b71" + "0011",
+		wantErr: ErrParseDataError,
+	},
+}
+
+func TestParseRevertReason(t *testing.T) {
+	for i, test := range revertReasonTests {
+		reason, err := ParseRevertReason(common.FromHex(test.data))
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("test %v: want error %v but got %v", i, test.wantErr, err)
+			continue
+		}
+		if err == nil && reason != test.reason {
+			t.Errorf("test %v: want reason %q but got %q", i, test.reason, reason)
+		}
+	}
+}
@@ -58,6 +58,10 @@ func (b *Bridge) Init() {
 	InitExtCodeParts()
 	b.InitLatestBlockNumber()
 
+	if err := checkGasStrategyConfig(b.ChainConfig); err != nil {
+		log.Crit("wrong chain config of gas strategy", "isSrc", b.IsSrc, "err", err)
+	}
+
 	if b.ChainConfig.BaseGasPrice != "" {
 		gasPrice, err := common.GetBigIntFromStr(b.ChainConfig.BaseGasPrice)
 		if err != nil {
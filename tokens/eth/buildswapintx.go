@@ -11,7 +11,7 @@ import (
 
 // build input for calling `Swapin(bytes32 txhash, address account, uint256 amount)`
 func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs) (err error) {
-	token := b.GetTokenConfig(args.PairID)
+	token := args.GetTokenConfig(b.IsSrc)
 	if token == nil {
 		return tokens.ErrUnknownPairID
 	}
@@ -22,15 +22,20 @@ func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs) (err error) {
 		return errInvalidReceiverAddress
 	}
 
-	swapValue := tokens.CalcSwappedValue(args.PairID, args.OriginValue, true, args.OriginFrom, args.OriginTxTo)
-	swapValue, err = b.adjustSwapValue(args, swapValue)
+	swapValue := tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, args.OriginFrom, args.OriginTxTo, args.TokenPrice)
+	funcHash := getSwapinFuncHash()
+	txHash := common.HexToHash(args.SwapID)
+	if args.GetTokenConfig(true).IsGasCostFeeMode() {
+		input := abicoder.PackDataWithFuncHash(funcHash, txHash, receiver, swapValue)
+		swapValue, err = b.deductGasCostFee(args, swapValue, token.ContractAddress, nil, input)
+	} else {
+		swapValue, err = b.adjustSwapValue(args, swapValue)
+	}
 	if err != nil {
 		return err
 	}
 	args.SwapValue = swapValue // swap value
 
-	funcHash := getSwapinFuncHash()
-	txHash := common.HexToHash(args.SwapID)
 	input := abicoder.PackDataWithFuncHash(funcHash, txHash, receiver, swapValue)
 	args.Input = &input             // input
 	args.To = token.ContractAddress // to
@@ -15,7 +15,7 @@ var (
 )
 
 func (b *Bridge) buildSwapoutTxInput(args *tokens.BuildTxArgs) (err error) {
-	token := b.GetTokenConfig(args.PairID)
+	token := args.GetTokenConfig(b.IsSrc)
 	if token == nil {
 		return tokens.ErrUnknownPairID
 	}
@@ -26,8 +26,18 @@ func (b *Bridge) buildSwapoutTxInput(args *tokens.BuildTxArgs) (err error) {
 		return errInvalidReceiverAddress
 	}
 
-	swapValue := tokens.CalcSwappedValue(args.PairID, args.OriginValue, false, args.OriginFrom, args.OriginTxTo)
-	swapValue, err = b.adjustSwapValue(args, swapValue)
+	swapValue := tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, args.OriginFrom, args.OriginTxTo, args.TokenPrice)
+	funcHash := erc20CodeParts["transfer"]
+	if token.IsGasCostFeeMode() {
+		if token.ContractAddress == "" {
+			swapValue, err = b.deductGasCostFee(args, swapValue, args.Bind, swapValue, b.getUnlockCoinMemo(args))
+		} else {
+			input := abicoder.PackDataWithFuncHash(funcHash, receiver, swapValue)
+			swapValue, err = b.deductGasCostFee(args, swapValue, token.ContractAddress, nil, input)
+		}
+	} else {
+		swapValue, err = b.adjustSwapValue(args, swapValue)
+	}
 	if err != nil {
 		return err
 	}
@@ -41,12 +51,11 @@ func (b *Bridge) buildSwapoutTxInput(args *tokens.BuildTxArgs) (err error) {
 		return nil
 	}
 
-	funcHash := erc20CodeParts["transfer"]
 	input := abicoder.PackDataWithFuncHash(funcHash, receiver, swapValue)
 	args.Input = &input             // input
 	args.To = token.ContractAddress // to
 
-	return b.checkBalance(token.ContractAddress, token.DcrmAddress, swapValue)
+	return b.checkBalance(token.ContractAddress, args.From, swapValue)
 }
 
 func (b *Bridge) getUnlockCoinMemo(args *tokens.BuildTxArgs) (input []byte) {
@@ -132,7 +132,7 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 		gasFee = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
 	}
 	if args.SwapType != tokens.NoSwapType {
-		needValue.Add(needValue, b.getMinReserveFee())
+		needValue.Add(needValue, b.GetMinReserveFee())
 		needValue.Add(needValue, new(big.Int).Mul(big.NewInt(5), gasFee))
 	} else {
 		needValue.Add(needValue, gasFee)
@@ -144,6 +144,14 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 		return nil, err
 	}
 
+	// simulate swap tx before signing to prevent failing on chain
+	if args.SwapType != tokens.NoSwapType {
+		err = b.SimulateTx(args.From, args.To, value, input, gasLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// assign nonce immediately before construct tx
 	// esp. for parallel signing, this can prevent nonce hole
 	if extra.Nonce == nil {
@@ -177,7 +185,8 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 	return rawTx, nil
 }
 
-func (b *Bridge) getMinReserveFee() *big.Int {
+// GetMinReserveFee get min reserve native balance of sender for gas
+func (b *Bridge) GetMinReserveFee() *big.Int {
 	if minReserveFee != nil {
 		return minReserveFee
 	}
@@ -273,6 +282,17 @@ func (b *Bridge) getGasPrice(args *tokens.BuildTxArgs) (price *big.Int, err erro
 		if args.GetReplaceNum() == 0 {
 			return price, nil
 		}
+	} else if b.isFeeHistoryGasStrategy() {
+		decision, errf := b.getGasStrategyDecision(args)
+		if errf != nil {
+			return nil, errf
+		}
+		price = new(big.Int).Set(decision.Value)
+
+		minGasPrice := b.ChainConfig.GetMinGasPrice()
+		if minGasPrice != nil && price.Cmp(minGasPrice) < 0 {
+			price = minGasPrice
+		}
 	} else {
 		for i := 0; i < retryRPCCount; i++ {
 			price, err = b.SuggestPrice()
@@ -315,7 +335,7 @@ func (b *Bridge) getGasPrice(args *tokens.BuildTxArgs) (price *big.Int, err erro
 
 // args and oldGasPrice should be read only
 func (b *Bridge) adjustSwapGasPrice(args *tokens.BuildTxArgs, oldGasPrice *big.Int) (newGasPrice *big.Int, err error) {
-	tokenCfg := b.GetTokenConfig(args.PairID)
+	tokenCfg := args.GetTokenConfig(b.IsSrc)
 	if tokenCfg == nil {
 		return nil, tokens.ErrUnknownPairID
 	}
@@ -366,9 +386,9 @@ func (b *Bridge) getAccountNonce(args *tokens.BuildTxArgs) (nonceptr *uint64, er
 		return nil, err
 	}
 	if args.SwapType != tokens.NoSwapType {
-		tokenCfg := b.GetTokenConfig(args.PairID)
-		if tokenCfg != nil && args.From == tokenCfg.DcrmAddress {
-			nonce = b.AdjustNonce(args.PairID, nonce)
+		tokenCfg := args.GetTokenConfig(b.IsSrc)
+		if tokenCfg != nil && tokenCfg.IsDcrmAddress(args.From) {
+			nonce = b.AdjustSenderNonce(args.From, nonce)
 		}
 	}
 	return &nonce, nil
@@ -397,15 +417,23 @@ func (b *Bridge) checkBalance(token, account string, amount *big.Int) (err error
 }
 
 func (b *Bridge) getGasTipCap(args *tokens.BuildTxArgs) (gasTipCap *big.Int, err error) {
-	for i := 0; i < retryRPCCount; i++ {
-		gasTipCap, err = b.SuggestGasTipCap()
-		if err == nil {
-			break
+	if b.isFeeHistoryGasStrategy() {
+		decision, errf := b.getGasStrategyDecision(args)
+		if errf != nil {
+			return nil, errf
+		}
+		gasTipCap = new(big.Int).Set(decision.Value)
+	} else {
+		for i := 0; i < retryRPCCount; i++ {
+			gasTipCap, err = b.SuggestGasTipCap()
+			if err == nil {
+				break
+			}
+			time.Sleep(retryRPCInterval)
+		}
+		if err != nil {
+			return nil, err
 		}
-		time.Sleep(retryRPCInterval)
-	}
-	if err != nil {
-		return nil, err
 	}
 	if args == nil || args.SwapType == tokens.NoSwapType {
 		return gasTipCap, err
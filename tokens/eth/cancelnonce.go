@@ -0,0 +1,122 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const cancelNonceGasLimit uint64 = 21000
+
+// BuildCancelNonceTx build zero value self transfer tx to fill the nonce gap
+// of the sender (dcrm address if not specified)
+func (b *Bridge) BuildCancelNonceTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if args.From != "" && !token.IsDcrmAddress(args.From) {
+		return nil, fmt.Errorf("cancel nonce tx sender %v is not dcrm address", args.From)
+	}
+	if args.Extra == nil || args.Extra.EthExtra == nil || args.Extra.EthExtra.Nonce == nil {
+		return nil, errors.New("cancel nonce tx without nonce")
+	}
+	extra := args.Extra.EthExtra
+	if extra.Gas == nil {
+		extra.Gas = new(uint64)
+		*extra.Gas = cancelNonceGasLimit
+	}
+	args.Identifier = tokens.CancelNonceIdentifier
+	args.SwapType = tokens.NoSwapType
+	args.From = getSenderOrDcrmAddress(token, args.From)
+	args.To = args.From
+	args.Value = big.NewInt(0)
+	args.Input = nil
+	return b.buildNonswapTx(args)
+}
+
+// VerifyCancelNonceMsgHash verify cancel nonce msg hash
+func (b *Bridge) VerifyCancelNonceMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if !token.IsDcrmAddress(args.From) || (args.To != "" && !strings.EqualFold(args.To, args.From)) {
+		return fmt.Errorf("cancel nonce tx must be self transfer of dcrm address, from %v to %v", args.From, args.To)
+	}
+	if args.Value != nil && args.Value.Sign() != 0 {
+		return errors.New("cancel nonce tx with non zero value")
+	}
+	if args.Input != nil && len(*args.Input) != 0 {
+		return errors.New("cancel nonce tx with non empty input")
+	}
+	if args.Extra == nil || args.Extra.EthExtra == nil || args.Extra.EthExtra.Nonce == nil {
+		return errors.New("empty eth extra")
+	}
+	extra := args.Extra.EthExtra
+	if extra.Gas == nil || *extra.Gas != cancelNonceGasLimit {
+		return errors.New("cancel nonce tx with wrong gas limit")
+	}
+	err := b.checkCancelNonceGasPrice(extra)
+	if err != nil {
+		return err
+	}
+	err = b.checkCancelNonce(args.From, *extra.Nonce)
+	if err != nil {
+		return err
+	}
+	rawTx, err := b.BuildCancelNonceTx(args)
+	if err != nil {
+		return err
+	}
+	return b.VerifyMsgHash(rawTx, msgHash)
+}
+
+// only nonce which is below the pending nonce of the sender
+// and has no confirmed tx on chain can be cancelled
+func (b *Bridge) checkCancelNonce(sender string, nonce uint64) error {
+	latest, err := b.GetPoolNonce(sender, "latest")
+	if err != nil {
+		return err
+	}
+	if nonce < latest {
+		return fmt.Errorf("cancel nonce %v is already confirmed, latest nonce is %v", nonce, latest)
+	}
+	pending, err := b.GetPoolNonce(sender, "pending")
+	if err != nil {
+		return err
+	}
+	pending = b.AdjustSenderNonce(sender, pending)
+	if nonce >= pending {
+		return fmt.Errorf("cancel nonce %v is not below pending nonce %v", nonce, pending)
+	}
+	return nil
+}
+
+func (b *Bridge) checkCancelNonceGasPrice(extra *tokens.EthExtraArgs) error {
+	if b.ChainConfig.EnableDynamicFeeTx {
+		if extra.GasTipCap == nil || extra.GasFeeCap == nil {
+			return errors.New("cancel nonce tx without gas tip cap or fee cap")
+		}
+		maxGasTipCap := b.ChainConfig.GetMaxGasTipCap()
+		if maxGasTipCap != nil && extra.GasTipCap.Cmp(maxGasTipCap) > 0 {
+			return fmt.Errorf("gas tip cap %v exceeded maximum limit", extra.GasTipCap)
+		}
+		maxGasFeeCap := b.ChainConfig.GetMaxGasFeeCap()
+		if maxGasFeeCap != nil && extra.GasFeeCap.Cmp(maxGasFeeCap) > 0 {
+			return fmt.Errorf("gas fee cap %v exceeded maximum limit", extra.GasFeeCap)
+		}
+		return nil
+	}
+	if extra.GasPrice == nil {
+		return errors.New("cancel nonce tx without gas price")
+	}
+	maxGasPrice := b.ChainConfig.GetMaxGasPrice()
+	if maxGasPrice != nil && extra.GasPrice.Cmp(maxGasPrice) > 0 {
+		return fmt.Errorf("gas price %v exceeded maximum limit", extra.GasPrice)
+	}
+	return nil
+}
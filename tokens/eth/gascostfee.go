@@ -0,0 +1,122 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/gasstrategy"
+)
+
+var ether = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// calc gas cost of the swap tx on this chain in the amount of swapped token,
+// gasCostFee = gas * gasPrice * nativePrice / tokenPrice * 10^decimals / 10^18
+func (b *Bridge) calcGasCostFee(args *tokens.BuildTxArgs, to string, value *big.Int, input []byte) (*big.Int, error) {
+	token := args.GetTokenConfig(b.IsSrc)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	extra := getOrInitExtra(args)
+	// native price and base fee are carried in args to make oracles
+	// calc the same fee, oracles only check them in tolerance range
+	nativePrice := extra.NativePrice
+	if nativePrice > 0 {
+		if err := tokens.CheckNativePrice(b.ChainConfig, nativePrice); err != nil {
+			return nil, err
+		}
+	} else {
+		nativePrice = b.ChainConfig.GetNativePrice()
+		if nativePrice <= 0 {
+			return nil, fmt.Errorf("%w: native price of chain %v", tokens.ErrMissTokenPrice, b.ChainConfig.BlockChain)
+		}
+		extra.NativePrice = nativePrice
+	}
+	tokenPrice := args.TokenPrice
+	if tokenPrice <= 0 {
+		tokenPrice = token.TokenPrice
+	}
+	if tokenPrice <= 0 {
+		return nil, fmt.Errorf("%w: pairID %v", tokens.ErrMissTokenPrice, args.PairID)
+	}
+
+	gas, err := b.EstimateGas(args.From, to, value, input)
+	if err != nil {
+		return nil, tokens.ErrEstimateGasFailed
+	}
+
+	var gasPrice *big.Int
+	if b.ChainConfig.EnableDynamicFeeTx {
+		baseFee, errf := b.getGasCostBaseFee(extra)
+		if errf != nil {
+			return nil, errf
+		}
+		gasPrice = new(big.Int).Set(baseFee)
+		if extra.GasTipCap != nil {
+			gasPrice.Add(gasPrice, extra.GasTipCap)
+		}
+	} else {
+		gasPrice = extra.GasPrice
+	}
+	if gasPrice == nil {
+		return nil, fmt.Errorf("%w: no gas price", tokens.ErrEstimateGasFailed)
+	}
+
+	gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	fee := new(big.Rat).SetInt(gasCost)
+	fee.Mul(fee, new(big.Rat).SetFloat64(nativePrice))
+	fee.Quo(fee, new(big.Rat).SetFloat64(tokenPrice))
+	fee.Mul(fee, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(*token.Decimals)), nil)))
+	fee.Quo(fee, new(big.Rat).SetInt(ether))
+
+	gasCostFee := new(big.Int).Quo(fee.Num(), fee.Denom())
+	log.Info("calc gas cost fee", "isSrc", b.IsSrc, "chainID", b.SignerChainID, "pairID", args.PairID,
+		"gas", gas, "gasPrice", gasPrice, "gasCost", gasCost, "nativePrice", nativePrice,
+		"tokenPrice", tokenPrice, "gasCostFee", gasCostFee)
+	return gasCostFee, nil
+}
+
+// get base fee to calc gas cost fee, check the specified one is in tolerance of local value
+func (b *Bridge) getGasCostBaseFee(extra *tokens.EthExtraArgs) (*big.Int, error) {
+	localBaseFee, err := b.GetBaseFee(0)
+	if err != nil {
+		return nil, err
+	}
+	if extra.GasCostBaseFee == nil {
+		extra.GasCostBaseFee = localBaseFee
+		return localBaseFee, nil
+	}
+	tolerance := getGasStrategyTolerance(b.ChainConfig)
+	if !gasstrategy.IsInTolerance(extra.GasCostBaseFee, localBaseFee, tolerance) {
+		return nil, fmt.Errorf("%w: base fee have %v, local %v, tolerance %v", tokens.ErrGasPriceMismatch, extra.GasCostBaseFee, localBaseFee, tolerance)
+	}
+	return extra.GasCostBaseFee, nil
+}
+
+// deduct gas cost fee from swap value. if gas cost fee is specified
+// (eg. rebuild tx when verifying), check it's in tolerance of local value.
+func (b *Bridge) deductGasCostFee(args *tokens.BuildTxArgs, swapValue *big.Int, to string, value *big.Int, input []byte) (*big.Int, error) {
+	gasCostFee, err := b.calcGasCostFee(args, to, value, input)
+	if err != nil {
+		return nil, err
+	}
+	extra := getOrInitExtra(args)
+	if extra.GasCostFee != nil {
+		if err = tokens.CheckValueTolerance(extra.GasCostFee, gasCostFee); err != nil {
+			return nil, fmt.Errorf("check gas cost fee failed, %w", err)
+		}
+		gasCostFee = extra.GasCostFee
+	} else {
+		extra.GasCostFee = gasCostFee
+	}
+
+	newSwapValue := new(big.Int).Sub(swapValue, gasCostFee)
+	log.Info("deduct gas cost fee", "isSrc", b.IsSrc, "chainID", b.SignerChainID,
+		"pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swapType", args.SwapType.String(),
+		"originValue", args.OriginValue, "oldSwapValue", swapValue, "newSwapValue", newSwapValue, "gasCostFee", gasCostFee)
+	if newSwapValue.Sign() <= 0 {
+		return nil, tokens.ErrWrongSwapValue
+	}
+	return newSwapValue, nil
+}
@@ -0,0 +1,191 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/gasstrategy"
+)
+
+const (
+	defaultGasStrategyBlockCount = 20
+	defaultGasStrategyTolerance  = 0.3
+)
+
+func (b *Bridge) isFeeHistoryGasStrategy() bool {
+	return gasstrategy.IsFeeHistoryStrategy(b.ChainConfig.GasStrategy)
+}
+
+// checkGasStrategyConfig check gas strategy config of chain
+func checkGasStrategyConfig(chainCfg *tokens.ChainConfig) error {
+	if !gasstrategy.IsValid(chainCfg.GasStrategy) {
+		return fmt.Errorf("unknown 'GasStrategy' %v", chainCfg.GasStrategy)
+	}
+	if chainCfg.GasStrategy == gasstrategy.TimeOfDay && len(chainCfg.GasBudgets) == 0 {
+		return errors.New("must config 'GasBudgets' if 'GasStrategy' is timeofday")
+	}
+	return nil
+}
+
+func getGasStrategyOptions(chainCfg *tokens.ChainConfig) *gasstrategy.Options {
+	opts := &gasstrategy.Options{
+		Strategy:     chainCfg.GasStrategy,
+		Percentile:   chainCfg.GasPercentile,
+		TargetBlocks: chainCfg.GasTargetBlocks,
+	}
+	for _, budget := range chainCfg.GasBudgets {
+		opts.Budgets = append(opts.Budgets, &gasstrategy.Budget{
+			FromHour:    budget.FromHour,
+			ToHour:      budget.ToHour,
+			Percentile:  budget.Percentile,
+			MaxGasPrice: budget.GetMaxGasPrice(),
+		})
+	}
+	return opts
+}
+
+func getGasStrategyTolerance(chainCfg *tokens.ChainConfig) float64 {
+	if chainCfg.GasStrategyTolerance > 0 {
+		return chainCfg.GasStrategyTolerance
+	}
+	return defaultGasStrategyTolerance
+}
+
+// suggest gas price (legacy tx) or gas tip cap (dynamic fee tx) by the configed gas strategy
+func (b *Bridge) suggestByGasStrategy() (decision *tokens.GasDecision, err error) {
+	chainCfg := b.ChainConfig
+	plan, err := getGasStrategyOptions(chainCfg).GetPlan(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	blockCount := chainCfg.BlockCountFeeHistory
+	if blockCount <= 0 {
+		blockCount = defaultGasStrategyBlockCount
+	}
+	feeHistory, err := b.FeeHistory(blockCount, []float64{plan.Percentile})
+	if err != nil {
+		return nil, err
+	}
+	rewards := make([]*big.Int, 0, len(feeHistory.Reward))
+	for _, reward := range feeHistory.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			rewards = append(rewards, reward[0].ToInt())
+		}
+	}
+	var baseFee *big.Int
+	if length := len(feeHistory.BaseFee); length > 0 {
+		baseFee = feeHistory.BaseFee[length-1].ToInt() // base fee of next block
+	}
+	value, err := plan.Suggest(baseFee, rewards, chainCfg.EnableDynamicFeeTx)
+	if err != nil {
+		return nil, err
+	}
+	decision = &tokens.GasDecision{
+		Strategy:    chainCfg.GasStrategy,
+		Value:       value,
+		BaseFee:     baseFee,
+		Percentile:  plan.Percentile,
+		MaxGasPrice: plan.MaxGasPrice,
+	}
+	log.Info("suggest gas by strategy", "isSrc", b.IsSrc, "chainID", b.SignerChainID,
+		"strategy", decision.Strategy, "value", value, "baseFee", baseFee,
+		"percentile", plan.Percentile, "maxGasPrice", plan.MaxGasPrice)
+	return decision, nil
+}
+
+func (b *Bridge) getGasStrategyDecision(args *tokens.BuildTxArgs) (decision *tokens.GasDecision, err error) {
+	for i := 0; i < retryRPCCount; i++ {
+		decision, err = b.suggestByGasStrategy()
+		if err == nil {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if args != nil {
+		getOrInitExtra(args).GasDecision = decision
+	}
+	return decision, nil
+}
+
+// VerifyGasPrice verify gas price decision with local gas strategy output
+func (b *Bridge) VerifyGasPrice(args *tokens.BuildTxArgs) error {
+	if !b.isFeeHistoryGasStrategy() || b.ChainConfig.IsFixedGasPrice() {
+		return nil
+	}
+	if args.Extra == nil || args.Extra.EthExtra == nil {
+		return nil
+	}
+	extra := args.Extra.EthExtra
+	decision := extra.GasDecision
+	if decision == nil {
+		if args.GetReplaceNum() > 0 {
+			return nil // replace with specified gas price
+		}
+		return fmt.Errorf("%w: no gas decision", tokens.ErrGasPriceMismatch)
+	}
+	if decision.Strategy != b.ChainConfig.GasStrategy || decision.Value == nil {
+		return fmt.Errorf("%w: strategy mismatch, have %v, want %v", tokens.ErrGasPriceMismatch, decision.Strategy, b.ChainConfig.GasStrategy)
+	}
+	local, err := b.suggestByGasStrategy()
+	if err != nil {
+		return err
+	}
+	tolerance := getGasStrategyTolerance(b.ChainConfig)
+	if !gasstrategy.IsInTolerance(decision.Value, local.Value, tolerance) {
+		return fmt.Errorf("%w: strategy output have %v, local %v, tolerance %v", tokens.ErrGasPriceMismatch, decision.Value, local.Value, tolerance)
+	}
+
+	// actual price can only be increased by plus and replace percentage
+	price := extra.GasPrice
+	if b.ChainConfig.EnableDynamicFeeTx {
+		price = extra.GasTipCap
+	}
+	if price == nil {
+		return fmt.Errorf("%w: no gas price", tokens.ErrGasPriceMismatch)
+	}
+	maxPrice := new(big.Int).Set(decision.Value)
+	if minGasPrice := b.ChainConfig.GetMinGasPrice(); !b.ChainConfig.EnableDynamicFeeTx && minGasPrice != nil && maxPrice.Cmp(minGasPrice) < 0 {
+		maxPrice.Set(minGasPrice)
+	}
+	maxPrice.Mul(maxPrice, big.NewInt(int64(100+tokens.MaxPlusGasPricePercentage)))
+	maxPrice.Div(maxPrice, big.NewInt(100))
+	if price.Cmp(maxPrice) > 0 {
+		return fmt.Errorf("%w: gas price %v exceeds %v", tokens.ErrGasPriceMismatch, price, maxPrice)
+	}
+	if b.ChainConfig.EnableDynamicFeeTx {
+		return b.verifyGasFeeCap(extra.GasFeeCap, local.BaseFee, maxPrice, tolerance)
+	}
+	return nil
+}
+
+// verifyGasFeeCap verify gas fee cap not exceed the one built with
+// the local base fee (plus tolerance) and the max gas tip cap
+func (b *Bridge) verifyGasFeeCap(gasFeeCap, baseFee, maxGasTipCap *big.Int, tolerance float64) error {
+	if gasFeeCap == nil {
+		return fmt.Errorf("%w: no gas fee cap", tokens.ErrGasPriceMismatch)
+	}
+	if baseFee == nil {
+		return fmt.Errorf("%w: no local base fee", tokens.ErrGasPriceMismatch)
+	}
+	maxBaseFee := new(big.Int).Mul(baseFee, big.NewInt(int64(100+tolerance*100)))
+	maxBaseFee.Div(maxBaseFee, big.NewInt(100))
+
+	maxGasFeeCap := new(big.Int).Mul(maxBaseFee, big.NewInt(2))
+	maxGasFeeCap.Add(maxGasFeeCap, maxGasTipCap)
+	maxGasFeeCap.Mul(maxGasFeeCap, big.NewInt(int64(100+b.ChainConfig.PlusGasFeeCapPercent)))
+	maxGasFeeCap.Div(maxGasFeeCap, big.NewInt(100))
+	if cfgMaxGasFeeCap := b.ChainConfig.GetMaxGasFeeCap(); cfgMaxGasFeeCap != nil && maxGasFeeCap.Cmp(cfgMaxGasFeeCap) > 0 {
+		maxGasFeeCap = cfgMaxGasFeeCap
+	}
+	if gasFeeCap.Cmp(maxGasFeeCap) > 0 {
+		return fmt.Errorf("%w: gas fee cap %v exceeds %v", tokens.ErrGasPriceMismatch, gasFeeCap, maxGasFeeCap)
+	}
+	return nil
+}
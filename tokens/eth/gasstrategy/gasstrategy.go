@@ -0,0 +1,162 @@
+// Package gasstrategy implements gas price strategies of eth like chains.
+package gasstrategy
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// gas strategy names
+const (
+	Median        = "median" // median of `eth_gasPrice` (default)
+	Percentile    = "percentile"
+	InclusionTime = "inclusiontime"
+	TimeOfDay     = "timeofday"
+)
+
+const defaultPercentile = 50.0
+
+var errNoRewards = errors.New("no rewards in fee history")
+
+// Budget gas budget of UTC hours in range [FromHour, ToHour),
+// FromHour larger than ToHour means the range crosses midnight.
+type Budget struct {
+	FromHour    int
+	ToHour      int
+	Percentile  float64
+	MaxGasPrice *big.Int
+}
+
+// Contains is hour in range of budget
+func (b *Budget) Contains(hour int) bool {
+	if b.FromHour <= b.ToHour {
+		return hour >= b.FromHour && hour < b.ToHour
+	}
+	return hour >= b.FromHour || hour < b.ToHour
+}
+
+// Options gas strategy options
+type Options struct {
+	Strategy     string
+	Percentile   float64
+	TargetBlocks uint64
+	Budgets      []*Budget
+}
+
+// Plan plan of the strategy
+type Plan struct {
+	Percentile  float64
+	MaxGasPrice *big.Int
+}
+
+// IsValid is valid strategy name
+func IsValid(strategy string) bool {
+	switch strategy {
+	case "", Median, Percentile, InclusionTime, TimeOfDay:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFeeHistoryStrategy is strategy based on fee history
+func IsFeeHistoryStrategy(strategy string) bool {
+	switch strategy {
+	case Percentile, InclusionTime, TimeOfDay:
+		return true
+	default:
+		return false
+	}
+}
+
+// InclusionPercentile reward percentile to be included in target blocks
+func InclusionPercentile(targetBlocks uint64) float64 {
+	switch {
+	case targetBlocks <= 1:
+		return 90
+	case targetBlocks <= 3:
+		return 75
+	case targetBlocks <= 10:
+		return 50
+	default:
+		return 25
+	}
+}
+
+// GetPlan get plan (reward percentile and gas price cap) at the specified time
+func (o *Options) GetPlan(now time.Time) (*Plan, error) {
+	switch o.Strategy {
+	case Percentile:
+		percentile := o.Percentile
+		if percentile == 0 {
+			percentile = defaultPercentile
+		}
+		return &Plan{Percentile: percentile}, nil
+	case InclusionTime:
+		return &Plan{Percentile: InclusionPercentile(o.TargetBlocks)}, nil
+	case TimeOfDay:
+		hour := now.UTC().Hour()
+		for _, budget := range o.Budgets {
+			if budget.Contains(hour) {
+				percentile := budget.Percentile
+				if percentile == 0 {
+					percentile = defaultPercentile
+				}
+				return &Plan{Percentile: percentile, MaxGasPrice: budget.MaxGasPrice}, nil
+			}
+		}
+		return &Plan{Percentile: defaultPercentile}, nil
+	default:
+		return nil, fmt.Errorf("gas strategy '%v' is not based on fee history", o.Strategy)
+	}
+}
+
+// MedianReward median of rewards of blocks (at the planned percentile)
+func MedianReward(rewards []*big.Int) (*big.Int, error) {
+	values := make([]*big.Int, 0, len(rewards))
+	for _, reward := range rewards {
+		if reward != nil {
+			values = append(values, reward)
+		}
+	}
+	if len(values) == 0 {
+		return nil, errNoRewards
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	count := len(values)
+	mdInd := (count - 1) / 2
+	if count%2 != 0 {
+		return new(big.Int).Set(values[mdInd]), nil
+	}
+	median := new(big.Int).Add(values[mdInd], values[mdInd+1])
+	return median.Div(median, big.NewInt(2)), nil
+}
+
+// Suggest suggest gas tip cap (dynamic fee tx) or gas price (legacy tx) by plan,
+// legacy gas price is the next base fee plus tip.
+func (p *Plan) Suggest(baseFee *big.Int, rewards []*big.Int, isDynamicFee bool) (*big.Int, error) {
+	value, err := MedianReward(rewards)
+	if err != nil {
+		return nil, err
+	}
+	if !isDynamicFee && baseFee != nil {
+		value.Add(value, baseFee)
+	}
+	if p.MaxGasPrice != nil && value.Cmp(p.MaxGasPrice) > 0 {
+		value = new(big.Int).Set(p.MaxGasPrice)
+	}
+	return value, nil
+}
+
+// IsInTolerance is value in tolerance range of the local value
+func IsInTolerance(value, localValue *big.Int, tolerance float64) bool {
+	diff := new(big.Int).Sub(value, localValue)
+	diff.Abs(diff)
+	maxDiff, _ := new(big.Float).Mul(new(big.Float).SetInt(localValue), big.NewFloat(tolerance)).Int(nil)
+	return diff.Cmp(maxDiff) <= 0
+}
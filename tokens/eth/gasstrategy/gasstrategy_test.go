@@ -0,0 +1,84 @@
+package gasstrategy
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func bigs(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, value := range values {
+		result[i] = big.NewInt(value)
+	}
+	return result
+}
+
+func TestMedianReward(t *testing.T) {
+	median, err := MedianReward(bigs(5, 1, 3))
+	if err != nil || median.Int64() != 3 {
+		t.Fatalf("wrong odd median: %v %v", median, err)
+	}
+	median, err = MedianReward(bigs(4, 1, 3, 2))
+	if err != nil || median.Int64() != 2 {
+		t.Fatalf("wrong even median: %v %v", median, err)
+	}
+	if _, err = MedianReward(nil); err == nil {
+		t.Fatal("median of empty rewards should fail")
+	}
+}
+
+func TestPlanSuggest(t *testing.T) {
+	plan := &Plan{Percentile: 50}
+	value, err := plan.Suggest(big.NewInt(100), bigs(10, 20, 30), true)
+	if err != nil || value.Int64() != 20 {
+		t.Fatalf("wrong dynamic fee suggestion: %v %v", value, err)
+	}
+	value, err = plan.Suggest(big.NewInt(100), bigs(10, 20, 30), false)
+	if err != nil || value.Int64() != 120 {
+		t.Fatalf("wrong legacy suggestion: %v %v", value, err)
+	}
+	plan.MaxGasPrice = big.NewInt(110)
+	value, err = plan.Suggest(big.NewInt(100), bigs(10, 20, 30), false)
+	if err != nil || value.Int64() != 110 {
+		t.Fatalf("budget cap is not applied: %v %v", value, err)
+	}
+}
+
+func TestTimeOfDayPlan(t *testing.T) {
+	opts := &Options{
+		Strategy: TimeOfDay,
+		Budgets: []*Budget{
+			{FromHour: 8, ToHour: 20, Percentile: 75, MaxGasPrice: big.NewInt(1000)},
+			{FromHour: 20, ToHour: 8, Percentile: 25},
+		},
+	}
+	plan, err := opts.GetPlan(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil || plan.Percentile != 75 || plan.MaxGasPrice.Int64() != 1000 {
+		t.Fatalf("wrong day plan: %v %v", plan, err)
+	}
+	plan, err = opts.GetPlan(time.Date(2021, 1, 1, 3, 0, 0, 0, time.UTC))
+	if err != nil || plan.Percentile != 25 || plan.MaxGasPrice != nil {
+		t.Fatalf("wrong night plan: %v %v", plan, err)
+	}
+}
+
+func TestInclusionTimePlan(t *testing.T) {
+	opts := &Options{Strategy: InclusionTime, TargetBlocks: 1}
+	plan, _ := opts.GetPlan(time.Now())
+	fast := plan.Percentile
+	opts.TargetBlocks = 20
+	plan, _ = opts.GetPlan(time.Now())
+	if plan.Percentile >= fast {
+		t.Fatalf("slower target should use lower percentile, fast %v slow %v", fast, plan.Percentile)
+	}
+}
+
+func TestIsInTolerance(t *testing.T) {
+	if !IsInTolerance(big.NewInt(110), big.NewInt(100), 0.1) {
+		t.Fatal("110 should be in 10% tolerance of 100")
+	}
+	if IsInTolerance(big.NewInt(111), big.NewInt(100), 0.1) {
+		t.Fatal("111 should be out of 10% tolerance of 100")
+	}
+}
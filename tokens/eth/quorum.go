@@ -0,0 +1,45 @@
+package eth
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// the content of receipt must be identical across gateways
+func receiptDigest(result interface{}) interface{} {
+	r := result.(*types.RPCTxReceipt)
+	return []interface{}{r.TxHash, r.TxIndex, r.BlockNumber, r.BlockHash, r.Status, r.From, r.Recipient, r.GasUsed, r.Logs}
+}
+
+// the content of transaction must be identical across gateways
+func transactionDigest(result interface{}) interface{} {
+	tx := result.(*types.RPCTransaction)
+	return []interface{}{tx.Hash, tx.BlockNumber, tx.BlockHash, tx.From, tx.Recipient, tx.Amount, tx.Payload}
+}
+
+// getQuorumReceipt get tx receipt which at least quorum gateways agree on
+func (b *Bridge) getQuorumReceipt(txHash string) (*types.RPCTxReceipt, error) {
+	gateway := b.GatewayConfig
+	result, err := tokens.QuorumCall(gateway.GetQuorumAPIAddress(), gateway.Quorum, "eth_getTransactionReceipt",
+		func(url string) (interface{}, error) {
+			receipt, _, errf := b.getTransactionReceipt(txHash, []string{url})
+			return receipt, errf
+		}, receiptDigest)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.RPCTxReceipt), nil
+}
+
+// getQuorumTransaction get tx which at least quorum gateways agree on
+func (b *Bridge) getQuorumTransaction(txHash string) (*types.RPCTransaction, error) {
+	gateway := b.GatewayConfig
+	result, err := tokens.QuorumCall(gateway.GetQuorumAPIAddress(), gateway.Quorum, "eth_getTransactionByHash",
+		func(url string) (interface{}, error) {
+			return b.getTransactionByHash(txHash, []string{url})
+		}, transactionDigest)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.RPCTransaction), nil
+}
@@ -0,0 +1,105 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+type callTraceResult struct {
+	Output       hexutil.Bytes `json:"output"`
+	Error        string        `json:"error"`
+	RevertReason string        `json:"revertReason"`
+}
+
+// GetTxRevertReason get revert reason of failed tx by re-executing it
+// with eth_call at its parent block, or by debug_traceTransaction.
+// return rpc error if no node answered, the caller should retry later.
+func (b *Bridge) GetTxRevertReason(txHash string) (reason string, err error) {
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		return "", err
+	}
+	if tx.BlockNumber == nil || tx.From == nil || tx.Recipient == nil {
+		return "", tokens.ErrRevertReasonNotFound
+	}
+	reason, callErr := b.getRevertReasonByCall(tx)
+	if callErr == nil {
+		return reason, nil
+	}
+	reason, err = b.getRevertReasonByTrace(txHash)
+	if err == nil {
+		return reason, nil
+	}
+	log.Info("get tx revert reason failed", "txHash", txHash, "callErr", callErr, "traceErr", err)
+	if errors.Is(callErr, tokens.ErrRevertReasonNotFound) {
+		return "", callErr
+	}
+	return "", err
+}
+
+func (b *Bridge) getRevertReasonByCall(tx *types.RPCTransaction) (string, error) {
+	reqArgs := map[string]interface{}{
+		"from": tx.From.String(),
+		"to":   tx.Recipient.String(),
+	}
+	if tx.Amount != nil {
+		reqArgs["value"] = tx.Amount
+	}
+	if tx.Payload != nil {
+		reqArgs["data"] = tx.Payload
+	}
+	if tx.GasLimit != nil {
+		reqArgs["gas"] = tx.GasLimit
+	}
+	parent := new(big.Int).Sub(tx.BlockNumber.ToInt(), big.NewInt(1))
+	blockNumber := hexutil.EncodeBig(parent)
+
+	apiAddresses := tools.GetGatewayPool(b.IsSrcEndpoint()).GetAPIAddresses(b.GatewayConfig.APIAddress)
+	var result hexutil.Bytes
+	var err error
+	for _, url := range apiAddresses {
+		err = client.RPCPost(&result, url, "eth_call", reqArgs, blockNumber)
+		if err == nil {
+			// replay succeed, the revert depends on earlier txs in the block
+			return "", tokens.ErrRevertReasonNotFound
+		}
+		if reason, isRevert := getRevertReason(err); isRevert {
+			return reason, nil
+		}
+	}
+	return "", wrapRPCQueryError(err, "eth_call", tx.Hash.String())
+}
+
+func (b *Bridge) getRevertReasonByTrace(txHash string) (string, error) {
+	tracer := map[string]interface{}{
+		"tracer": "callTracer",
+	}
+	apiAddresses := tools.GetGatewayPool(b.IsSrcEndpoint()).GetAPIAddresses(b.GatewayConfig.APIAddress)
+	var result callTraceResult
+	var err error
+	for _, url := range apiAddresses {
+		err = client.RPCPost(&result, url, "debug_traceTransaction", txHash, tracer)
+		if err != nil {
+			continue
+		}
+		switch {
+		case result.RevertReason != "":
+			return result.RevertReason, nil
+		case len(result.Output) > 0:
+			return abicoder.ParseRevertReason(result.Output)
+		case result.Error != "":
+			return result.Error, nil
+		default:
+			return "", tokens.ErrRevertReasonNotFound
+		}
+	}
+	return "", wrapRPCQueryError(err, "debug_traceTransaction", txHash)
+}
@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// EncodeTx encode raw or signed tx to hex string
+func (b *Bridge) EncodeTx(tx interface{}) (string, error) {
+	ethTx, ok := tx.(*types.Transaction)
+	if !ok {
+		return "", tokens.ErrWrongRawTx
+	}
+	data, err := ethTx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return common.ToHex(data), nil
+}
+
+// DecodeTx decode raw or signed tx from hex string
+func (b *Bridge) DecodeTx(data string) (interface{}, error) {
+	tx := new(types.Transaction)
+	err := tx.UnmarshalBinary(common.FromHex(data))
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// ResumeDcrmSignTransaction get signature of the sign request of keyID and complete the signed tx
+func (b *Bridge) ResumeDcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs, keyID string) (signTx interface{}, txHash string, err error) {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok {
+		return nil, "", tokens.ErrWrongRawTx
+	}
+	token := args.GetTokenConfig(b.IsSrc)
+	if token == nil {
+		return nil, "", tokens.ErrUnknownPairID
+	}
+	if keyID == "" {
+		return nil, "", errors.New("resume dcrm sign without keyID")
+	}
+	// gas price may be updated in dcrm signing
+	gasPrice := args.GetTxGasPrice()
+	if !b.ChainConfig.EnableDynamicFeeTx && gasPrice != nil && tx.GasPrice().Cmp(gasPrice) != 0 {
+		tx.SetGasPrice(gasPrice)
+	}
+	rsvs, err := dcrm.GetSignStatusByKeyID(keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" ResumeDcrmSignTransaction get sign status success", "keyID", keyID, "txid", args.SwapID)
+	return b.signTxWithRsvs(tx, args, getSenderOrDcrmAddress(token, args.From), keyID, rsvs)
+}
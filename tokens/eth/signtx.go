@@ -23,13 +23,15 @@ func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.Build
 	if tx.To() == nil || *tx.To() == (common.Address{}) {
 		return nil, fmt.Errorf("[sign] verify tx receiver failed")
 	}
-	tokenCfg := b.GetTokenConfig(args.PairID)
+	tokenCfg := args.GetTokenConfig(b.IsSrc)
 	if tokenCfg == nil {
 		return nil, fmt.Errorf("[sign] verify tx with unknown pairID '%v'", args.PairID)
 	}
 	checkReceiver := tokenCfg.ContractAddress
 	if args.SwapType == tokens.SwapoutType && !tokenCfg.IsErc20() {
 		checkReceiver = args.Bind
+	} else if args.Identifier == tokens.CancelNonceIdentifier {
+		checkReceiver = args.From
 	}
 	if !strings.EqualFold(tx.To().String(), checkReceiver) {
 		return nil, fmt.Errorf("[sign] verify tx receiver failed")
@@ -51,18 +53,26 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 			tx.SetGasPrice(gasPrice)
 		}
 	}
+	token := args.GetTokenConfig(b.IsSrc)
+	sender := getSenderOrDcrmAddress(token, args.From)
 	signer := b.Signer
 	msgHash := signer.Hash(tx)
-	jsondata, _ := json.Marshal(args.GetExtraArgs())
+	extraArgs := args.GetExtraArgs()
+	extraArgs.From = args.From // sender in dcrm address pool
+	jsondata, _ := json.Marshal(extraArgs)
 	msgContext := string(jsondata)
 
 	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash.String(), "txid", args.SwapID)
-	keyID, rsvs, err := dcrm.DoSignOne(b.GetDcrmPublicKey(args.PairID), msgHash.String(), msgContext)
+	keyID, rsvs, err := dcrm.DoSignOne(token.GetDcrmPubkeyOf(sender), msgHash.String(), msgContext)
 	if err != nil {
 		return nil, "", err
 	}
 	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "keyID", keyID, "msghash", msgHash.String(), "txid", args.SwapID)
 
+	return b.signTxWithRsvs(tx, args, sender, keyID, rsvs)
+}
+
+func (b *Bridge) signTxWithRsvs(tx *types.Transaction, args *tokens.BuildTxArgs, sender, keyID string, rsvs []string) (signTx interface{}, txHash string, err error) {
 	if len(rsvs) != 1 {
 		return nil, "", fmt.Errorf("get sign status require one rsv but have %v (keyID = %v)", len(rsvs), keyID)
 	}
@@ -75,8 +85,7 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 		return nil, "", errors.New("wrong signature of keyID " + keyID)
 	}
 
-	token := b.GetTokenConfig(args.PairID)
-	signedTx, err := b.signTxWithSignature(tx, signature, common.HexToAddress(token.DcrmAddress))
+	signedTx, err := b.signTxWithSignature(tx, signature, common.HexToAddress(sender))
 	if err != nil {
 		return nil, "", err
 	}
@@ -112,9 +121,28 @@ func (b *Bridge) signTxWithSignature(tx *types.Transaction, signature []byte, si
 	return nil, errors.New("wrong sender address")
 }
 
+func getSenderOrDcrmAddress(token *tokens.TokenConfig, sender string) string {
+	if sender != "" && token.IsDcrmAddress(sender) {
+		return sender
+	}
+	return token.DcrmAddress
+}
+
 // SignTransaction sign tx with pairID
 func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx interface{}, txHash string, err error) {
-	privKey := b.GetTokenConfig(pairID).GetDcrmAddressPrivateKey()
+	return b.SignTransactionWithSender(rawTx, pairID, "")
+}
+
+// SignTransactionWithSender sign tx with private key of sender in dcrm address pool
+func (b *Bridge) SignTransactionWithSender(rawTx interface{}, pairID, sender string) (signTx interface{}, txHash string, err error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, "", tokens.ErrUnknownPairID
+	}
+	privKey := token.GetPrivateKeyOf(getSenderOrDcrmAddress(token, sender))
+	if privKey == nil {
+		return nil, "", fmt.Errorf("no private key of sender %v", sender)
+	}
 	ecPrikey, err := crypto.HexToECDSA(*privKey)
 	if err != nil {
 		return nil, "", err
@@ -171,7 +199,13 @@ func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{})
 		return "", errors.New("wrong signature of keyID " + keyID)
 	}
 	token := b.GetTokenConfig(pairID)
-	signedTx, err := b.signTxWithSignature(tx, signature, common.HexToAddress(token.DcrmAddress))
+	var signedTx *types.Transaction
+	for _, sender := range token.GetDcrmAddresses() {
+		signedTx, err = b.signTxWithSignature(tx, signature, common.HexToAddress(sender))
+		if err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return "", err
 	}
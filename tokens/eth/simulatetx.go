@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+// SimulateTx simulate tx by eth_call at pending block,
+// return error wrapping tokens.ErrTxWouldRevert if the tx would revert.
+func (b *Bridge) SimulateTx(from, to string, value *big.Int, data []byte, gas uint64) error {
+	reqArgs := map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"value": (*hexutil.Big)(value),
+		"data":  hexutil.Bytes(data),
+		"gas":   hexutil.Uint64(gas),
+	}
+	apiAddresses := tools.GetGatewayPool(b.IsSrcEndpoint()).GetAPIAddresses(b.GatewayConfig.APIAddress)
+	var result hexutil.Bytes
+	var err error
+	for _, url := range apiAddresses {
+		err = client.RPCPost(&result, url, "eth_call", reqArgs, "pending")
+		if err == nil {
+			return nil
+		}
+		if reason, isRevert := getRevertReason(err); isRevert {
+			log.Warn("[rpc] simulate tx would revert", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "reason", reason)
+			return fmt.Errorf("%w: %v", tokens.ErrTxWouldRevert, reason)
+		}
+	}
+	log.Warn("[rpc] simulate tx failed", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "err", err)
+	return wrapRPCQueryError(err, "eth_call", from, to)
+}
+
+// getRevertReason get revert reason from json rpc error of eth_call
+func getRevertReason(err error) (reason string, isRevert bool) {
+	var jsonErr *client.JSONError
+	if !errors.As(err, &jsonErr) {
+		return "", false
+	}
+	if revertData, ok := jsonErr.Data.(string); ok && strings.HasPrefix(revertData, "0x") {
+		if reason, errp := abicoder.ParseRevertReason(common.FromHex(revertData)); errp == nil {
+			return reason, true
+		}
+	}
+	if strings.Contains(jsonErr.Message, "revert") {
+		return jsonErr.Message, true
+	}
+	return "", false
+}
@@ -46,7 +46,7 @@ func (b *Bridge) verifySwapoutTxReceipt(swapInfo *tokens.TxSwapInfo, receipt *ty
 	swapInfo.To = txRecipient                              // To
 	swapInfo.From = strings.ToLower(receipt.From.String()) // From
 
-	if common.IsEqualIgnoreCase(swapInfo.From, token.DcrmAddress) {
+	if token.IsDcrmAddress(swapInfo.From) {
 		return tokens.ErrTxWithWrongSender
 	}
 
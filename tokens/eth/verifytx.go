@@ -61,6 +61,9 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
 
 func getTxByHash(b *Bridge, txHash string, withExt bool) (*types.RPCTransaction, error) {
 	gateway := b.GatewayConfig
+	if withExt && gateway.IsQuorumMode() {
+		return b.getQuorumTransaction(txHash)
+	}
 	tx, err := b.getTransactionByHash(txHash, gateway.APIAddress)
 	if err != nil && withExt && len(gateway.APIAddressExt) > 0 {
 		tx, err = b.getTransactionByHash(txHash, gateway.APIAddressExt)
@@ -178,6 +181,13 @@ func (b *Bridge) getStableReceipt(swapInfo *tokens.TxSwapInfo) (*types.RPCTxRece
 		return nil, tokens.ErrTxNotStable
 	}
 	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	if ok && b.GatewayConfig.IsQuorumMode() {
+		receipt, err = b.getQuorumReceipt(swapInfo.Hash)
+		if err != nil {
+			return nil, err
+		}
+		swapInfo.Height = receipt.BlockNumber.ToInt().Uint64() // Height
+	}
 	if !ok || !receipt.IsStatusOk() {
 		return nil, tokens.ErrTxWithWrongReceipt
 	}
@@ -36,6 +36,8 @@ type NonceSetter interface {
 	GetPoolNonce(address, height string) (uint64, error)
 	SetNonce(pairID string, value uint64)
 	AdjustNonce(pairID string, value uint64) (nonce uint64)
+	SetSenderNonce(sender string, value uint64)
+	AdjustSenderNonce(sender string, value uint64) (nonce uint64)
 	InitNonces(nonces map[string]uint64)
 }
 
@@ -43,3 +45,37 @@ type NonceSetter interface {
 type ForkChecker interface {
 	GetBlockHashOf(urls []string, height uint64) (hash string, err error)
 }
+
+// GasPriceVerifier gas price verifier interface (for eth-like)
+type GasPriceVerifier interface {
+	VerifyGasPrice(args *BuildTxArgs) error
+}
+
+// NonceCanceller nonce canceller interface (for eth-like)
+type NonceCanceller interface {
+	BuildCancelNonceTx(args *BuildTxArgs) (rawTx interface{}, err error)
+	VerifyCancelNonceMsgHash(msgHash []string, args *BuildTxArgs) error
+}
+
+// SenderSigner sign tx by sender in dcrm address pool (for eth-like)
+type SenderSigner interface {
+	SignTransactionWithSender(rawTx interface{}, pairID, sender string) (signedTx interface{}, txHash string, err error)
+}
+
+// SignResumer resume signing swap tx after restart (for eth-like)
+type SignResumer interface {
+	EncodeTx(tx interface{}) (data string, err error)
+	DecodeTx(data string) (tx interface{}, err error)
+	ResumeDcrmSignTransaction(rawTx interface{}, args *BuildTxArgs, keyID string) (signedTx interface{}, txHash string, err error)
+}
+
+// RevertReasonGetter get revert reason of failed tx (for eth-like),
+// return ErrRevertReasonNotFound only if the node answered without a reason
+type RevertReasonGetter interface {
+	GetTxRevertReason(txHash string) (reason string, err error)
+}
+
+// AggregateTxChecker check if tx is utxo aggregate tx (for btc-like)
+type AggregateTxChecker interface {
+	IsAggregateTx(txHash string) (bool, error)
+}
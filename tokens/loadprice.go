@@ -5,7 +5,9 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
@@ -30,12 +32,8 @@ func callContract(contract string, urls []string, data hexutil.Bytes, blockNumbe
 	return "", err
 }
 
-func (c *TokenPairConfig) loadTokenPrice() (err error) {
-	if TokenPriceCfg == nil {
-		return nil
-	}
-
-	var srcTokenPrice, dstTokenPrice float64
+// loadTokenPrice load token prices of pair without modifying the config
+func (c *TokenPairConfig) loadTokenPrice() (srcTokenPrice, dstTokenPrice float64, err error) {
 	srcChainID := GetCrossChainBridge(true).GetChainConfig().GetChainID()
 	srcTokenAddress := c.SrcToken.ContractAddress
 	dstChainID := GetCrossChainBridge(false).GetChainConfig().GetChainID()
@@ -47,7 +45,7 @@ func (c *TokenPairConfig) loadTokenPrice() (err error) {
 		dstTokenPrice, _ = loadTokenPrice(dstChainID, dstTokenAddress)
 	}
 	if srcTokenPrice == 0 && dstTokenPrice == 0 {
-		return ErrMissTokenPrice
+		return 0, 0, ErrMissTokenPrice
 	}
 	if srcTokenPrice == 0 {
 		log.Info("srcTokenPrice is not config, use dstTokenPrice", "pairID", c.PairID)
@@ -58,15 +56,39 @@ func (c *TokenPairConfig) loadTokenPrice() (err error) {
 		dstTokenPrice = srcTokenPrice
 	}
 
-	c.SrcToken.TokenPrice = srcTokenPrice
-	c.DestToken.TokenPrice = dstTokenPrice
-
 	log.Info("load token pair price success", "pairID", c.PairID,
 		"srcTokenAddress", srcTokenAddress, "dstTokenAddress", dstTokenAddress,
 		"srcTokenName", c.SrcToken.Name, "dstTokenName", c.DestToken.Name,
-		"srcTokenPrice", c.SrcToken.TokenPrice, "dstTokenPrice", c.DestToken.TokenPrice,
+		"srcTokenPrice", srcTokenPrice, "dstTokenPrice", dstTokenPrice,
 	)
-	return nil
+	return srcTokenPrice, dstTokenPrice, nil
+}
+
+// withTokenPrices copy pair config with the token prices and the calced values,
+// the config in use is never modified as it is read concurrently.
+func (c *TokenPairConfig) withTokenPrices(srcTokenPrice, dstTokenPrice float64) *TokenPairConfig {
+	pairCfg := *c
+	srcToken := *c.SrcToken
+	dstToken := *c.DestToken
+	srcToken.TokenPrice = srcTokenPrice
+	dstToken.TokenPrice = dstTokenPrice
+	srcToken.CalcAndStoreValue()
+	dstToken.CalcAndStoreValue()
+	pairCfg.SrcToken = &srcToken
+	pairCfg.DestToken = &dstToken
+	return &pairCfg
+}
+
+// updateTokenPrices swap in pair config with the token prices if pair config is not changed
+func updateTokenPrices(oldCfg *TokenPairConfig, srcTokenPrice, dstTokenPrice float64) bool {
+	pairID := strings.ToLower(oldCfg.PairID)
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	if GetTokenPairsConfig()[pairID] != oldCfg {
+		return false // updated by others, retry in next reload
+	}
+	setTokenPairConfig(pairID, oldCfg.withTokenPrices(srcTokenPrice, dstTokenPrice))
+	return true
 }
 
 func loadTokenPrice(chainID *big.Int, tokenAddress string) (float64, error) {
@@ -97,18 +119,41 @@ func loadTokenPrice(chainID *big.Int, tokenAddress string) (float64, error) {
 	return price, nil
 }
 
+// load native coin prices (of zero token address) of eth-like chains
+func loadNativePrices() {
+	for _, isSrc := range []bool{true, false} {
+		bridge := GetCrossChainBridge(isSrc)
+		if bridge == nil || GetNonceSetter(isSrc) == nil {
+			continue
+		}
+		chainCfg := bridge.GetChainConfig()
+		chainID := chainCfg.GetChainID()
+		if chainID == nil {
+			continue
+		}
+		price, err := loadTokenPrice(chainID, "")
+		if err != nil {
+			log.Warn("load native price failed", "isSrc", isSrc, "chainID", chainID, "err", err)
+			continue
+		}
+		chainCfg.SetNativePrice(price)
+	}
+}
+
 func initAllTokenPrices() {
 	if TokenPriceCfg == nil {
 		return
 	}
+	loadNativePrices()
 	for _, pairCfg := range GetTokenPairsConfig() {
-		err := pairCfg.loadTokenPrice()
+		srcTokenPrice, dstTokenPrice, err := pairCfg.loadTokenPrice()
 		if err != nil {
 			log.Fatal("init token price failed", "pairID", pairCfg.PairID, "err", err)
 		}
+		if !updateTokenPrices(pairCfg, srcTokenPrice, dstTokenPrice) {
+			log.Fatal("init token price failed", "pairID", pairCfg.PairID, "err", "pair config is changed")
+		}
 		log.Info("init token price success", "pairID", pairCfg.PairID)
-		pairCfg.SrcToken.CalcAndStoreValue()
-		pairCfg.DestToken.CalcAndStoreValue()
 	}
 	log.Info("init all token price success")
 }
@@ -133,21 +178,21 @@ func reloadTokenPrices(pairIDs []string) {
 			}
 		}
 	}
+	loadNativePrices()
 	reloadAllSuccess := true
 	for _, pairCfg := range pairCfgs {
-		oldSrcPrice := pairCfg.SrcToken.TokenPrice
-		oldDstPrice := pairCfg.DestToken.TokenPrice
-		err := pairCfg.loadTokenPrice()
+		srcTokenPrice, dstTokenPrice, err := pairCfg.loadTokenPrice()
 		if err != nil {
 			reloadAllSuccess = false
 			log.Error("reload token price failed", "pairID", pairCfg.PairID, "err", err)
 			continue
 		}
-		if pairCfg.SrcToken.TokenPrice != oldSrcPrice {
-			pairCfg.SrcToken.CalcAndStoreValue()
+		if srcTokenPrice == pairCfg.SrcToken.TokenPrice && dstTokenPrice == pairCfg.DestToken.TokenPrice {
+			continue
 		}
-		if pairCfg.DestToken.TokenPrice != oldDstPrice {
-			pairCfg.DestToken.CalcAndStoreValue()
+		if !updateTokenPrices(pairCfg, srcTokenPrice, dstTokenPrice) {
+			reloadAllSuccess = false
+			log.Warn("reload token price failed as pair config is changed", "pairID", pairCfg.PairID)
 		}
 	}
 	if reloadAllSuccess {
@@ -159,6 +204,13 @@ func reloadTokenPrices(pairIDs []string) {
 	}
 }
 
+func loopReloadTokenPrices(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		reloadTokenPrices(nil)
+	}
+}
+
 func watchAndReloadTokenPrices() {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGUSR1)
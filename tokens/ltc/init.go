@@ -4,6 +4,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/coinselect"
 )
 
 var (
@@ -18,6 +19,12 @@ var (
 	cfgUtxoAggregateMinCount  = 20
 	cfgUtxoAggregateMinValue  = uint64(1000000)
 	cfgUtxoAggregateToAddress string
+
+	cfgCoinSelection        = coinselect.Greedy
+	cfgHighFeeRatePerKb     int64
+	cfgLowFeeRatePerKb      int64
+	cfgConsolidateMaxInputs = 20
+	cfgConsolidateMaxValue  = uint64(100000)
 )
 
 // Init init ltc extra
@@ -33,6 +40,7 @@ func Init(btcExtra *tokens.BtcExtraConfig) {
 	initFromPublicKey()
 	initRelayFee(btcExtra)
 	initAggregate(btcExtra)
+	initCoinSelection(btcExtra)
 }
 
 func initFromPublicKey() {
@@ -107,3 +115,28 @@ func initAggregate(btcExtra *tokens.BtcExtraConfig) {
 
 	log.Info("Init Btc extra", "UtxoAggregateMinCount", cfgUtxoAggregateMinCount, "UtxoAggregateMinValue", cfgUtxoAggregateMinValue, "UtxoAggregateToAddress", cfgUtxoAggregateToAddress)
 }
+
+func initCoinSelection(btcExtra *tokens.BtcExtraConfig) {
+	if btcExtra.CoinSelection != "" {
+		cfgCoinSelection = btcExtra.CoinSelection
+		if !coinselect.IsSupported(cfgCoinSelection) {
+			log.Fatal("unsupported coin selection strategy", "CoinSelection", cfgCoinSelection)
+		}
+	}
+
+	cfgHighFeeRatePerKb = btcExtra.HighFeeRatePerKb
+	cfgLowFeeRatePerKb = btcExtra.LowFeeRatePerKb
+	if cfgHighFeeRatePerKb > 0 && cfgLowFeeRatePerKb > cfgHighFeeRatePerKb {
+		log.Fatal("LowFeeRatePerKb is larger than HighFeeRatePerKb", "low", cfgLowFeeRatePerKb, "high", cfgHighFeeRatePerKb)
+	}
+
+	if btcExtra.ConsolidateMaxInputs > 0 {
+		cfgConsolidateMaxInputs = btcExtra.ConsolidateMaxInputs
+	}
+
+	if btcExtra.ConsolidateMaxValue > 0 {
+		cfgConsolidateMaxValue = btcExtra.ConsolidateMaxValue
+	}
+
+	log.Info("Init Btc extra", "CoinSelection", cfgCoinSelection, "HighFeeRatePerKb", cfgHighFeeRatePerKb, "LowFeeRatePerKb", cfgLowFeeRatePerKb, "ConsolidateMaxInputs", cfgConsolidateMaxInputs, "ConsolidateMaxValue", cfgConsolidateMaxValue)
+}
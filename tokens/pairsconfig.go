@@ -7,6 +7,8 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -16,7 +18,8 @@ import (
 var (
 	tokenPairsConfigDirectory string
 
-	tokenPairsConfig map[string]*TokenPairConfig
+	// value is map[string]*TokenPairConfig, copy on write under pairsUpdateLock
+	tokenPairsConfig atomic.Value
 )
 
 // TokenPairConfig pair config
@@ -25,6 +28,14 @@ type TokenPairConfig struct {
 	DiffDecimals bool
 	SrcToken     *TokenConfig
 	DestToken    *TokenConfig
+
+	// config version, must increase when updating pair config dynamically
+	Version uint64 `json:",omitempty"`
+	// unsafe changes apply after activation (source chain height or unix time)
+	ActivateHeight uint64 `json:",omitempty"`
+	ActivateTime   int64  `json:",omitempty"`
+
+	Retired bool `toml:"-" json:",omitempty"`
 }
 
 // SetTokenPairsDir set token pairs directory
@@ -53,17 +64,32 @@ func SetTokenPairsConfig(pairsConfig map[string]*TokenPairConfig, check bool) {
 			log.Fatalf("check token pairs config error: %v", err)
 		}
 	}
-	tokenPairsConfig = pairsConfig
+	pairsUpdateLock.Lock()
+	tokenPairsConfig.Store(pairsConfig)
+	pairsUpdateLock.Unlock()
 }
 
-// GetTokenPairsConfig get token pairs config
+// GetTokenPairsConfig get token pairs config, the result is read only
 func GetTokenPairsConfig() map[string]*TokenPairConfig {
-	return tokenPairsConfig
+	pairsConfig, _ := tokenPairsConfig.Load().(map[string]*TokenPairConfig)
+	return pairsConfig
+}
+
+// setTokenPairConfig replace pair config by copy on write,
+// must be called with pairsUpdateLock held.
+func setTokenPairConfig(pairID string, pairConfig *TokenPairConfig) {
+	oldPairsConfig := GetTokenPairsConfig()
+	pairsConfig := make(map[string]*TokenPairConfig, len(oldPairsConfig)+1)
+	for id, pairCfg := range oldPairsConfig {
+		pairsConfig[id] = pairCfg
+	}
+	pairsConfig[strings.ToLower(pairID)] = pairConfig
+	tokenPairsConfig.Store(pairsConfig)
 }
 
 // GetTokenPairConfig get token pair config
 func GetTokenPairConfig(pairID string) *TokenPairConfig {
-	pairCfg, exist := tokenPairsConfig[strings.ToLower(pairID)]
+	pairCfg, exist := GetTokenPairsConfig()[strings.ToLower(pairID)]
 	if !exist {
 		log.Warn("GetTokenPairConfig: pairID not exist", "pairID", pairID)
 		return nil
@@ -73,14 +99,15 @@ func GetTokenPairConfig(pairID string) *TokenPairConfig {
 
 // IsTokenPairExist is token pair exist
 func IsTokenPairExist(pairID string) bool {
-	_, exist := tokenPairsConfig[strings.ToLower(pairID)]
+	_, exist := GetTokenPairsConfig()[strings.ToLower(pairID)]
 	return exist
 }
 
 // GetAllPairIDs get all pairIDs
 func GetAllPairIDs() []string {
-	pairIDs := make([]string, 0, len(tokenPairsConfig))
-	for _, pairCfg := range tokenPairsConfig {
+	pairsConfig := GetTokenPairsConfig()
+	pairIDs := make([]string, 0, len(pairsConfig))
+	for _, pairCfg := range pairsConfig {
 		pairIDs = append(pairIDs, strings.ToLower(pairCfg.PairID))
 	}
 	return pairIDs
@@ -88,7 +115,7 @@ func GetAllPairIDs() []string {
 
 // FindTokenConfig find by (tx to) address
 func FindTokenConfig(address string, isSrc bool) (configs []*TokenConfig, pairIDs []string) {
-	for _, pairCfg := range tokenPairsConfig {
+	for _, pairCfg := range GetTokenPairsConfig() {
 		var tokenCfg *TokenConfig
 		if isSrc {
 			tokenCfg = pairCfg.SrcToken
@@ -113,7 +140,7 @@ func FindTokenConfig(address string, isSrc bool) (configs []*TokenConfig, pairID
 
 // GetTokenConfig get token config
 func GetTokenConfig(pairID string, isSrc bool) *TokenConfig {
-	pairCfg, exist := tokenPairsConfig[strings.ToLower(pairID)]
+	pairCfg, exist := GetTokenPairsConfig()[strings.ToLower(pairID)]
 	if !exist {
 		log.Trace("GetTokenConfig: pairID not exist", "pairID", pairID)
 		return nil
@@ -126,7 +153,7 @@ func GetTokenConfig(pairID string, isSrc bool) *TokenConfig {
 
 // GetTokenConfigsByDirection get token configs by direction
 func GetTokenConfigsByDirection(pairID string, isSwapin bool) (fromTokenConfig, toTokenConfig *TokenConfig) {
-	pairCfg, exist := tokenPairsConfig[strings.ToLower(pairID)]
+	pairCfg, exist := GetTokenPairsConfig()[strings.ToLower(pairID)]
 	if !exist {
 		log.Trace("GetTokenConfigs: pairID not exist", "pairID", pairID)
 		return nil, nil
@@ -216,10 +243,17 @@ func LoadTokenPairsConfig(check bool) {
 	if err != nil {
 		log.Fatal("load token pair config error", "err", err)
 	}
+	err = restorePairsActivation(pairsConfig)
+	if err != nil {
+		log.Fatal("restore token pairs activation error", "err", err)
+	}
 	SetTokenPairsConfig(pairsConfig, check)
 	if TokenPriceCfg != nil {
 		initAllTokenPrices()
 		go watchAndReloadTokenPrices()
+		if TokenPriceCfg.ReloadInterval > 0 {
+			go loopReloadTokenPrices(time.Duration(TokenPriceCfg.ReloadInterval) * time.Second)
+		}
 	}
 }
 
@@ -253,6 +287,7 @@ func LoadTokenPairsConfigInDir(dir string, check bool) (map[string]*TokenPairCon
 			return nil, fmt.Errorf("duplicate pairID '%v'", pairConfig.PairID)
 		}
 		pairsConfig[pairID] = pairConfig
+		recordPairConfigFile(filePath, pairID)
 	}
 	if check {
 		err = checkTokenPairsConfig(pairsConfig)
@@ -289,12 +324,21 @@ func AddPairConfig(configFile string) (pairConfig *TokenPairConfig, err error) {
 	if err != nil {
 		return nil, err
 	}
+	pairsUpdateLock.Lock()
 	err = checkAddTokenPairsConfig(pairConfig)
 	if err != nil {
+		pairsUpdateLock.Unlock()
+		return nil, err
+	}
+	err = savePairConfigState(pairConfig.PairID, &pairConfigState{Current: pairConfig})
+	if err != nil {
+		pairsUpdateLock.Unlock()
 		return nil, err
 	}
 	// use all small case to identify
-	tokenPairsConfig[strings.ToLower(pairConfig.PairID)] = pairConfig
+	setTokenPairConfig(pairConfig.PairID, pairConfig)
+	pairsUpdateLock.Unlock()
+	recordPairConfigFile(configFile, pairConfig.PairID)
 	log.Info("add pair config success", "pairID", pairConfig.PairID, "configFile", configFile)
 	return pairConfig, nil
 }
@@ -313,7 +357,8 @@ func checkAddTokenPairsConfig(pairConfig *TokenPairConfig) (err error) {
 		return err
 	}
 	pairID := strings.ToLower(pairConfig.PairID)
-	if _, exist := tokenPairsConfig[pairID]; exist {
+	pairsConfig := GetTokenPairsConfig()
+	if _, exist := pairsConfig[pairID]; exist {
 		return fmt.Errorf("pairID '%v' already exist", pairID)
 	}
 	srcContract := strings.ToLower(pairConfig.SrcToken.ContractAddress)
@@ -325,7 +370,7 @@ func checkAddTokenPairsConfig(pairConfig *TokenPairConfig) (err error) {
 		return fmt.Errorf("must close withdraw if is delegate swapin")
 	}
 	dstContract := strings.ToLower(pairConfig.DestToken.ContractAddress)
-	for _, tokenPair := range tokenPairsConfig {
+	for _, tokenPair := range pairsConfig {
 		if strings.EqualFold(srcContract, tokenPair.SrcToken.ContractAddress) {
 			return fmt.Errorf("source contract '%v' already exist", srcContract)
 		}
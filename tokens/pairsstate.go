@@ -0,0 +1,120 @@
+package tokens
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// sub directory of token pairs dir to persist pair config states
+const pairsStateDirName = ".pairstate"
+
+// pairConfigState keep activation of unsafe changes across restarts
+type pairConfigState struct {
+	// config used by swaps registered before the activation of current config
+	Previous *TokenPairConfig `toml:",omitempty"`
+	// latest applied config
+	Current *TokenPairConfig
+}
+
+func getPairStateFile(pairID string) string {
+	return filepath.Join(tokenPairsConfigDirectory, pairsStateDirName, strings.ToLower(pairID)+".toml")
+}
+
+func loadPairConfigState(pairID string) (*pairConfigState, error) {
+	if tokenPairsConfigDirectory == "" {
+		return nil, nil
+	}
+	stateFile := getPairStateFile(pairID)
+	if !common.FileExist(stateFile) {
+		return nil, nil
+	}
+	state := &pairConfigState{}
+	if _, err := toml.DecodeFile(stateFile, state); err != nil {
+		return nil, fmt.Errorf("toml decode pair state file %v error: %w", stateFile, err)
+	}
+	if state.Current == nil {
+		return nil, fmt.Errorf("pair state file %v has no current config", stateFile)
+	}
+	for _, pairCfg := range []*TokenPairConfig{state.Previous, state.Current} {
+		if pairCfg == nil {
+			continue
+		}
+		if err := pairCfg.CheckConfig(); err != nil {
+			return nil, fmt.Errorf("wrong pair state file %v: %w", stateFile, err)
+		}
+	}
+	return state, nil
+}
+
+func savePairConfigState(pairID string, state *pairConfigState) error {
+	if tokenPairsConfigDirectory == "" {
+		return nil
+	}
+	stateDir := filepath.Join(tokenPairsConfigDirectory, pairsStateDirName)
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(state); err != nil {
+		return err
+	}
+	stateFile := getPairStateFile(pairID)
+	tmpFile := stateFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, stateFile)
+}
+
+// restorePairsActivation restore pending activations at startup,
+// config files updated when stopped are treated as updated dynamically.
+func restorePairsActivation(pairsConfig map[string]*TokenPairConfig) error {
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	for pairID, pairCfg := range pairsConfig {
+		state, err := loadPairConfigState(pairID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case state == nil:
+			state = &pairConfigState{}
+		case pairCfg.Version > state.Current.Version:
+			oldConfig := state.Current
+			if pairCfg.hasUnsafeChanges(oldConfig) {
+				if err = pairCfg.checkActivation(); err != nil {
+					return fmt.Errorf("pair %v: %w", pairID, err)
+				}
+				state.Previous, err = pairCfg.mergeUnsafeFields(oldConfig)
+				if err != nil {
+					return fmt.Errorf("pair %v: %w", pairID, err)
+				}
+			}
+		case pairCfg.Version < state.Current.Version:
+			log.Warn("pair config version is lower than the applied one, ignore previous config", "pairID", pairID,
+				"version", pairCfg.Version, "appliedVersion", state.Current.Version)
+			state = &pairConfigState{}
+		}
+		state.Current = pairCfg
+		if state.Previous != nil && pairCfg.HasActivation() {
+			// unsafe changes are applied by the activation job
+			previousPairsConfig[pairID] = state.Previous
+			pendingPairsConfig[pairID] = pairCfg
+			pairsConfig[pairID] = state.Previous
+		} else {
+			state.Previous = nil
+		}
+		if err = savePairConfigState(pairID, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package tokens
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+var (
+	// key is pairID, value is pair config waiting for activation
+	pendingPairsConfig = make(map[string]*TokenPairConfig)
+	// key is pairID, value is pair config before the latest activation,
+	// which is used by the swaps registered before the activation
+	previousPairsConfig = make(map[string]*TokenPairConfig)
+	// key is config file path, value is pairID
+	pairConfigFiles = make(map[string]string)
+
+	pairsUpdateLock sync.Mutex
+)
+
+// GetTokenPairVersion get config version of token pair
+func GetTokenPairVersion(pairID string) uint64 {
+	pairCfg, exist := GetTokenPairsConfig()[strings.ToLower(pairID)]
+	if !exist {
+		return 0
+	}
+	return pairCfg.Version
+}
+
+// GetTokenPairConfigOfSwap get pair config used by swap with tx at the specified height and time,
+// unsafe changes only apply to swaps registered after the activation,
+// earlier ones use the previous pair config.
+// the choice depends on chain data only, not on whether it is activated locally.
+func GetTokenPairConfigOfSwap(pairID string, isSwapin bool, height, timestamp uint64) *TokenPairConfig {
+	pairID = strings.ToLower(pairID)
+	pairCfg, exist := GetTokenPairsConfig()[pairID]
+	if !exist {
+		return nil
+	}
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	if pending, exist := pendingPairsConfig[pairID]; exist {
+		if pending.isSwapActivated(isSwapin, height, timestamp) {
+			return pending
+		}
+		return pairCfg
+	}
+	if !pairCfg.HasActivation() || pairCfg.isSwapActivated(isSwapin, height, timestamp) {
+		return pairCfg
+	}
+	if prevCfg, exist := previousPairsConfig[pairID]; exist {
+		return prevCfg
+	}
+	return pairCfg
+}
+
+// GetPendingPairConfig get pair config waiting for activation
+func GetPendingPairConfig(pairID string) *TokenPairConfig {
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	return pendingPairsConfig[strings.ToLower(pairID)]
+}
+
+// HasActivation has activation height or time
+func (c *TokenPairConfig) HasActivation() bool {
+	return c.ActivateHeight > 0 || c.ActivateTime > 0
+}
+
+// IsActivated is activated at the specified height and time
+func (c *TokenPairConfig) IsActivated(height uint64, timestamp int64) bool {
+	if c.ActivateHeight > 0 && height < c.ActivateHeight {
+		return false
+	}
+	if c.ActivateTime > 0 && timestamp < c.ActivateTime {
+		return false
+	}
+	return true
+}
+
+// isSwapActivated is the swap with tx at the specified height and time registered after the activation,
+// activate height is of the source chain, so swapouts are decided by the activate time only.
+func (c *TokenPairConfig) isSwapActivated(isSwapin bool, height, timestamp uint64) bool {
+	if isSwapin && c.ActivateHeight > 0 && height < c.ActivateHeight {
+		return false
+	}
+	if c.ActivateTime > 0 && int64(timestamp) < c.ActivateTime {
+		return false
+	}
+	return true
+}
+
+// checkActivation check activation of unsafe changes
+func (c *TokenPairConfig) checkActivation() error {
+	if !c.HasActivation() {
+		return fmt.Errorf("pair config has unsafe changes, must config 'ActivateHeight' or 'ActivateTime'")
+	}
+	if c.ActivateTime == 0 && !c.DestToken.DisableSwap {
+		return fmt.Errorf("pair config has unsafe changes, must config 'ActivateTime' to activate swapouts")
+	}
+	return nil
+}
+
+func recordPairConfigFile(configFile, pairID string) {
+	if absPath, err := filepath.Abs(configFile); err == nil {
+		configFile = absPath
+	}
+	pairsUpdateLock.Lock()
+	pairConfigFiles[filepath.Clean(configFile)] = strings.ToLower(pairID)
+	pairsUpdateLock.Unlock()
+}
+
+// GetPairIDOfConfigFile get pairID of loaded config file
+func GetPairIDOfConfigFile(configFile string) string {
+	if absPath, err := filepath.Abs(configFile); err == nil {
+		configFile = absPath
+	}
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	return pairConfigFiles[filepath.Clean(configFile)]
+}
+
+// UpdatePairConfig update existing pair config dynamically.
+// safe fields apply immediately, unsafe fields (eg. dcrm address,
+// contract address) apply after the activation height or time.
+func UpdatePairConfig(configFile string) (pairConfig *TokenPairConfig, err error) {
+	newConfig, err := loadTokenPairConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	err = newConfig.CheckConfig()
+	if err != nil {
+		return nil, err
+	}
+	err = SrcBridge.VerifyTokenConfig(newConfig.SrcToken)
+	if err != nil {
+		return nil, err
+	}
+	err = DstBridge.VerifyTokenConfig(newConfig.DestToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+
+	pairID := strings.ToLower(newConfig.PairID)
+	oldConfig, exist := GetTokenPairsConfig()[pairID]
+	if !exist {
+		return nil, fmt.Errorf("pairID '%v' not exist", pairID)
+	}
+	if newConfig.Version <= oldConfig.Version {
+		return nil, fmt.Errorf("pair config version %v is not greater than current version %v", newConfig.Version, oldConfig.Version)
+	}
+	newConfig.keepRuntimeValues(oldConfig)
+
+	if !newConfig.hasUnsafeChanges(oldConfig) {
+		err = savePairConfigState(pairID, &pairConfigState{
+			Previous: previousPairsConfig[pairID],
+			Current:  newConfig,
+		})
+		if err != nil {
+			return nil, err
+		}
+		delete(pendingPairsConfig, pairID)
+		setTokenPairConfig(pairID, newConfig)
+		log.Info("update pair config success", "pairID", pairID, "version", newConfig.Version, "configFile", configFile)
+		return newConfig, nil
+	}
+
+	err = newConfig.checkActivation()
+	if err != nil {
+		return nil, err
+	}
+	err = checkUpdateTokenPairsConfig(newConfig)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := newConfig.mergeUnsafeFields(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+	// persist before applying to keep the activation across restarts
+	err = savePairConfigState(pairID, &pairConfigState{
+		Previous: merged,
+		Current:  newConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pendingPairsConfig[pairID] = newConfig
+	previousPairsConfig[pairID] = merged
+	setTokenPairConfig(pairID, merged)
+	log.Info("update pair config success, unsafe changes wait for activation", "pairID", pairID, "version", newConfig.Version,
+		"activateHeight", newConfig.ActivateHeight, "activateTime", newConfig.ActivateTime, "configFile", configFile)
+	return merged, nil
+}
+
+// ActivatePendingPairConfigs activate pending pair configs,
+// height is the latest block height of source chain.
+// the replaced config is kept for swaps registered before the activation.
+func ActivatePendingPairConfigs(height uint64) (activated []*TokenPairConfig) {
+	now := time.Now().Unix()
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+	for pairID, pairCfg := range pendingPairsConfig {
+		if !pairCfg.IsActivated(height, now) {
+			continue
+		}
+		if oldConfig, exist := GetTokenPairsConfig()[pairID]; exist {
+			pairCfg.keepRuntimeValues(oldConfig)
+		}
+		setTokenPairConfig(pairID, pairCfg)
+		delete(pendingPairsConfig, pairID)
+		activated = append(activated, pairCfg)
+		log.Info("activate pair config success", "pairID", pairID, "version", pairCfg.Version, "height", height, "timestamp", now)
+	}
+	return activated
+}
+
+// RetirePairConfig retire pair by disabling swap in both directions,
+// the config is kept to process the swaps on the way.
+func RetirePairConfig(pairID string) (pairConfig *TokenPairConfig, err error) {
+	pairID = strings.ToLower(pairID)
+	pairsUpdateLock.Lock()
+	defer pairsUpdateLock.Unlock()
+
+	oldConfig, exist := GetTokenPairsConfig()[pairID]
+	if !exist {
+		return nil, fmt.Errorf("pairID '%v' not exist", pairID)
+	}
+	srcToken := *oldConfig.SrcToken
+	dstToken := *oldConfig.DestToken
+	srcToken.DisableSwap = true
+	dstToken.DisableSwap = true
+	pairConfig = &TokenPairConfig{
+		PairID:         oldConfig.PairID,
+		DiffDecimals:   oldConfig.DiffDecimals,
+		Version:        oldConfig.Version,
+		ActivateHeight: oldConfig.ActivateHeight,
+		ActivateTime:   oldConfig.ActivateTime,
+		Retired:        true,
+		SrcToken:       &srcToken,
+		DestToken:      &dstToken,
+	}
+	delete(pendingPairsConfig, pairID)
+	setTokenPairConfig(pairID, pairConfig)
+	log.Info("retire pair config success", "pairID", pairID, "version", pairConfig.Version)
+	return pairConfig, nil
+}
+
+// keep values calced or loaded at runtime
+func (c *TokenPairConfig) keepRuntimeValues(old *TokenPairConfig) {
+	c.SrcToken.TokenPrice = old.SrcToken.TokenPrice
+	c.DestToken.TokenPrice = old.DestToken.TokenPrice
+	c.SrcToken.CalcAndStoreValue()
+	c.DestToken.CalcAndStoreValue()
+}
+
+func (c *TokenPairConfig) hasUnsafeChanges(old *TokenPairConfig) bool {
+	return c.DiffDecimals != old.DiffDecimals ||
+		c.SrcToken.hasUnsafeChanges(old.SrcToken) ||
+		c.DestToken.hasUnsafeChanges(old.DestToken)
+}
+
+// unsafe fields may change the verifying or building of swaps
+func (c *TokenConfig) hasUnsafeChanges(old *TokenConfig) bool {
+	return c.ID != old.ID ||
+		*c.Decimals != *old.Decimals ||
+		!strings.EqualFold(c.DepositAddress, old.DepositAddress) ||
+		!strings.EqualFold(c.DcrmAddress, old.DcrmAddress) ||
+		c.DcrmPubkey != old.DcrmPubkey ||
+		!strings.EqualFold(c.ContractAddress, old.ContractAddress) ||
+		c.ContractCodeHash != old.ContractCodeHash ||
+		c.IsDelegateContract != old.IsDelegateContract ||
+		!strings.EqualFold(c.DelegateToken, old.DelegateToken) ||
+		c.IsAnyswapAdapter != old.IsAnyswapAdapter ||
+		c.IsMappingTokenProxy != old.IsMappingTokenProxy ||
+		c.DcrmAddressPriKey != old.DcrmAddressPriKey ||
+		!reflect.DeepEqual(c.DcrmAddressPool, old.DcrmAddressPool) ||
+		!reflect.DeepEqual(c.RippleExtra, old.RippleExtra)
+}
+
+// mergeUnsafeFields merge safe fields of new config and unsafe fields of old config
+func (c *TokenPairConfig) mergeUnsafeFields(old *TokenPairConfig) (*TokenPairConfig, error) {
+	merged := &TokenPairConfig{
+		PairID:       c.PairID,
+		DiffDecimals: old.DiffDecimals,
+		Version:      c.Version,
+		SrcToken:     c.SrcToken.mergeUnsafeFields(old.SrcToken),
+		DestToken:    c.DestToken.mergeUnsafeFields(old.DestToken),
+	}
+	err := merged.CheckConfig()
+	if err != nil {
+		return nil, err
+	}
+	merged.keepRuntimeValues(old)
+	return merged, nil
+}
+
+func (c *TokenConfig) mergeUnsafeFields(old *TokenConfig) *TokenConfig {
+	merged := *c
+	merged.ID = old.ID
+	merged.Decimals = old.Decimals
+	merged.DepositAddress = old.DepositAddress
+	merged.DcrmAddress = old.DcrmAddress
+	merged.DcrmPubkey = old.DcrmPubkey
+	merged.ContractAddress = old.ContractAddress
+	merged.ContractCodeHash = old.ContractCodeHash
+	merged.IsDelegateContract = old.IsDelegateContract
+	merged.DelegateToken = old.DelegateToken
+	merged.IsAnyswapAdapter = old.IsAnyswapAdapter
+	merged.IsMappingTokenProxy = old.IsMappingTokenProxy
+	merged.DcrmAddressPriKey = old.DcrmAddressPriKey
+	merged.DcrmAddressPool = old.DcrmAddressPool
+	merged.RippleExtra = old.RippleExtra
+	return &merged
+}
+
+func checkUpdateTokenPairsConfig(pairConfig *TokenPairConfig) error {
+	pairID := strings.ToLower(pairConfig.PairID)
+	isDelegateSwapin := pairConfig.SrcToken.IsDelegateContract
+	if isDelegateSwapin && !pairConfig.DestToken.DisableSwap {
+		return fmt.Errorf("must close withdraw if is delegate swapin")
+	}
+	srcContract := pairConfig.SrcToken.ContractAddress
+	dstContract := pairConfig.DestToken.ContractAddress
+	for id, tokenPair := range GetTokenPairsConfig() {
+		if id == pairID {
+			continue
+		}
+		if srcContract != "" && strings.EqualFold(srcContract, tokenPair.SrcToken.ContractAddress) {
+			return fmt.Errorf("source contract '%v' already exist", srcContract)
+		}
+		if !isDelegateSwapin && strings.EqualFold(dstContract, tokenPair.DestToken.ContractAddress) {
+			return fmt.Errorf("destination contract '%v' already exist", dstContract)
+		}
+	}
+	return nil
+}
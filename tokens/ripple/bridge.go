@@ -353,11 +353,17 @@ func (b *Bridge) GetAccountLine(currency, issuer, accountAddress string) (*data.
 				break
 			}
 		}
+		if err == nil && acclRes != nil {
+			break
+		}
 		time.Sleep(rpcRetryInterval)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if acclRes == nil {
+		return nil, fmt.Errorf("get account lines failed")
+	}
 	for _, accl := range acclRes.Lines {
 		asset := accl.Asset()
 		if asset.Currency == currency && asset.Issuer == issuer {
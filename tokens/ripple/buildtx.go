@@ -32,7 +32,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		amount   *big.Int
 	)
 
-	token := b.GetTokenConfig(pairID)
+	token := args.GetTokenConfig(b.IsSrcEndpoint())
 	if token == nil {
 		return nil, fmt.Errorf("swap pair '%v' is not configed", pairID)
 	}
@@ -41,10 +41,10 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	case tokens.SwapinType:
 		return nil, tokens.ErrSwapTypeNotSupported
 	case tokens.SwapoutType:
-		from = token.DcrmAddress                                                    // from
-		to = args.Bind                                                              // to
-		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false, from, to) // amount
-		pubkey = b.GetDcrmPublicKey(pairID)
+		from = token.DcrmAddress                                                                            // from
+		to = args.Bind                                                                                      // to
+		amount = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, from, to, args.TokenPrice) // amount
+		pubkey = token.DcrmPubkey
 	default:
 		return nil, tokens.ErrUnknownSwapType
 	}
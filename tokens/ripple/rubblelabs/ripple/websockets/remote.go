@@ -193,6 +193,18 @@ func (r *Remote) accountTx(account data.Account, c chan *data.TransactionWithMet
 	}
 }
 
+// Synchronously get one page of transactions for an account,
+// use the marker of the result to get the next page.
+func (r *Remote) AccountTxPage(account data.Account, pageSize int, marker map[string]interface{}, minLedger, maxLedger int64) (*AccountTxResult, error) {
+	cmd := newAccountTxCommand(account, pageSize, marker, minLedger, maxLedger)
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, cmd.CommandError
+	}
+	return cmd.Result, nil
+}
+
 // Retrieve all transactions for an account via
 // https://ripple.com/build/rippled-apis/#account-tx. Will call
 // `account_tx` multiple times, if a marker is returned.  Transactions
@@ -481,6 +493,22 @@ func (r *Remote) Subscribe(ledger, transactions, transactionsProposed, server bo
 	return cmd.Result, nil
 }
 
+// Synchronously subscribe to validated transactions affecting the accounts
+// Streams are recived asynchronously over the Incoming channel
+func (r *Remote) SubscribeAccounts(accounts []data.Account) (*SubscribeResult, error) {
+	cmd := &SubscribeCommand{
+		Command:  newCommand("subscribe"),
+		Streams:  []string{},
+		Accounts: accounts,
+	}
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, cmd.CommandError
+	}
+	return cmd.Result, nil
+}
+
 type OrderBookSubscription struct {
 	TakerGets data.Asset `json:"taker_gets"`
 	TakerPays data.Asset `json:"taker_pays"`
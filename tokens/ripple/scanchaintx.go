@@ -0,0 +1,245 @@
+package ripple
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/data"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/websockets"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+var (
+	scannedTxs = tools.NewCachedScannedTxs(500)
+
+	maxScanLedgers          = uint64(1000) // max ledgers of one poll
+	accountTxPageSize       = 200
+	retryIntervalInScanJob  = 3 * time.Second
+	pollIntervalInScanJob   = 10 * time.Second
+	pollIntervalWhenSubOK   = 60 * time.Second
+	resubscribeIntervalInSc = 10 * time.Second
+
+	isSubscribed int32
+)
+
+// StartChainTransactionScanJob scan job
+func (b *Bridge) StartChainTransactionScanJob() {
+	accounts := b.getDepositAccounts()
+	if len(accounts) == 0 {
+		log.Warn("[scanchain] no deposit account to scan")
+		return
+	}
+	log.Info("[scanchain] start ripple scan chain job", "accounts", accounts)
+
+	go b.loopSubscribeAccounts(accounts)
+	b.loopPollAccountTx(accounts)
+}
+
+func (b *Bridge) getDepositAccounts() (accounts []data.Account) {
+	exist := make(map[string]struct{})
+	for _, pairID := range tokens.GetAllPairIDs() {
+		token := b.GetTokenConfig(pairID)
+		if token == nil {
+			continue
+		}
+		if _, ok := exist[token.DepositAddress]; ok {
+			continue
+		}
+		account, err := data.NewAccountFromAddress(token.DepositAddress)
+		if err != nil {
+			log.Warn("[scanchain] wrong deposit address", "pairID", pairID, "address", token.DepositAddress, "err", err)
+			continue
+		}
+		exist[token.DepositAddress] = struct{}{}
+		accounts = append(accounts, *account)
+	}
+	return accounts
+}
+
+// subscribe to validated transactions of deposit accounts,
+// and resubscribe if the connection is lost.
+func (b *Bridge) loopSubscribeAccounts(accounts []data.Account) {
+	for {
+		for _, apiAddress := range b.GetGatewayConfig().APIAddress {
+			b.subscribeAccounts(apiAddress, accounts)
+		}
+		time.Sleep(resubscribeIntervalInSc)
+	}
+}
+
+func (b *Bridge) subscribeAccounts(apiAddress string, accounts []data.Account) {
+	remote, err := websockets.NewRemote(apiAddress)
+	if err != nil || remote == nil {
+		log.Warn("[scanchain] connect remote failed", "address", apiAddress, "err", err)
+		return
+	}
+	defer remote.Close()
+
+	_, err = remote.SubscribeAccounts(accounts)
+	if err != nil {
+		log.Warn("[scanchain] subscribe accounts failed", "address", apiAddress, "err", err)
+		return
+	}
+	log.Info("[scanchain] subscribe accounts success", "address", apiAddress)
+
+	atomic.StoreInt32(&isSubscribed, 1)
+	defer atomic.StoreInt32(&isSubscribed, 0)
+
+	for msg := range remote.Incoming {
+		txMsg, ok := msg.(*websockets.TransactionStreamMsg)
+		if !ok || !txMsg.Validated {
+			continue
+		}
+		txid := txMsg.Transaction.GetBase().Hash.String()
+		log.Info("[scanchain] receive subscribed transaction", "txid", txid, "ledger", txMsg.LedgerSequence)
+		_ = b.processTransaction(txid)
+	}
+	log.Warn("[scanchain] subscription is closed", "address", apiAddress)
+}
+
+// poll `account_tx` from the persisted scanned ledger,
+// this is the fallback if subscription is not available.
+// missed ledgers (eg. after downtime) are polled in pages of `maxScanLedgers`.
+func (b *Bridge) loopPollAccountTx(accounts []data.Account) {
+	start, _ := b.getStartAndLatestHeight()
+	_ = tools.UpdateLatestScanInfo(b.IsSrc, start)
+
+	confirmations := *b.GetChainConfig().Confirmations
+	stable := start
+	for {
+		latest := tools.LoopGetLatestBlockNumber(b)
+		if latest > stable {
+			to := latest
+			if to > stable+maxScanLedgers {
+				to = stable + maxScanLedgers
+			}
+			ok := true
+			for _, account := range accounts {
+				if !b.pollAccountTx(account, stable+1, to) {
+					ok = false
+				}
+			}
+			if !ok {
+				time.Sleep(retryIntervalInScanJob)
+				continue
+			}
+			newStable := to
+			if newStable+confirmations > latest {
+				newStable = 0
+				if latest > confirmations {
+					newStable = latest - confirmations
+				}
+			}
+			if newStable > stable {
+				stable = newStable
+				_ = tools.UpdateLatestScanInfo(b.IsSrc, stable)
+			}
+			if to < latest {
+				continue // catch up missed ledgers
+			}
+		}
+		if atomic.LoadInt32(&isSubscribed) == 1 {
+			time.Sleep(pollIntervalWhenSubOK)
+		} else {
+			time.Sleep(pollIntervalInScanJob)
+		}
+	}
+}
+
+// pollAccountTx return true only if all txs in range are fetched and processed,
+// otherwise the scanned ledger should not move forward.
+func (b *Bridge) pollAccountTx(account data.Account, minLedger, maxLedger uint64) bool {
+	for url, remote := range b.Remotes {
+		txids, err := getAccountTxids(remote, account, minLedger, maxLedger)
+		if err != nil {
+			log.Warn("[scanchain] poll account tx failed", "account", account.String(), "from", minLedger, "to", maxLedger, "remote", url, "err", err)
+			continue
+		}
+		ok := true
+		for _, txid := range txids {
+			if !b.processTransaction(txid) {
+				ok = false
+			}
+		}
+		log.Info("[scanchain] poll account tx", "account", account.String(), "from", minLedger, "to", maxLedger, "txs", len(txids), "ok", ok)
+		return ok
+	}
+	log.Warn("[scanchain] poll account tx failed, no available remote", "account", account.String())
+	return false
+}
+
+// getAccountTxids get txids of all pages, fail if any page failed
+func getAccountTxids(remote *websockets.Remote, account data.Account, minLedger, maxLedger uint64) ([]string, error) {
+	var (
+		txids  []string
+		marker map[string]interface{}
+	)
+	for {
+		result, err := remote.AccountTxPage(account, accountTxPageSize, marker, int64(minLedger), int64(maxLedger))
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range result.Transactions {
+			txids = append(txids, tx.GetBase().Hash.String())
+		}
+		if result.Marker == nil {
+			return txids, nil
+		}
+		marker = result.Marker
+	}
+}
+
+func (b *Bridge) getStartAndLatestHeight() (start, latest uint64) {
+	startHeight := tools.GetLatestScanHeight(b.IsSrc)
+
+	chainCfg := b.GetChainConfig()
+	confirmations := *chainCfg.Confirmations
+	initialHeight := *chainCfg.InitialHeight
+
+	latest = tools.LoopGetLatestBlockNumber(b)
+
+	switch {
+	case startHeight != 0:
+		start = startHeight
+	case initialHeight != 0:
+		start = initialHeight
+	default:
+		if latest > confirmations {
+			start = latest - confirmations
+		}
+	}
+	if start < initialHeight {
+		start = initialHeight
+	}
+	if start+maxScanLedgers < latest {
+		log.Info("[scanchain] resume scanning from far behind ledger", "start", start, "latest", latest)
+	}
+	return start, latest
+}
+
+// processTransaction return false if should retry later
+func (b *Bridge) processTransaction(txid string) bool {
+	if scannedTxs.IsTxScanned(txid) {
+		return true
+	}
+	var (
+		swapInfos []*tokens.TxSwapInfo
+		errs      []error
+	)
+	for _, pairID := range tokens.GetAllPairIDs() {
+		swapInfo, err := b.verifySwapinTxWithPairID(pairID, txid, true)
+		if tokens.ShouldRegisterSwapForError(err) {
+			swapInfos = append(swapInfos, swapInfo)
+			errs = append(errs, err)
+		} else if tokens.IsRPCQueryOrNotFoundError(err) {
+			return false // retry in later scan
+		}
+	}
+	if len(swapInfos) > 0 {
+		tools.RegisterSwapin(txid, swapInfos, errs)
+	}
+	scannedTxs.CacheScannedTx(txid)
+	return true
+}
@@ -11,7 +11,7 @@ import (
 
 // SendTransaction send signed tx
 func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error) {
-	tx, ok := signedTx.(*data.Payment)
+	tx, ok := signedTx.(data.Transaction)
 	if !ok {
 		return "", fmt.Errorf("Send transaction type assertion error")
 	}
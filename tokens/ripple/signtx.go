@@ -20,6 +20,9 @@ import (
 )
 
 func (b *Bridge) verifyTransactionWithArgs(tx data.Transaction, args *tokens.BuildTxArgs) error {
+	if trustSet, ok := tx.(*data.TrustSet); ok {
+		return b.verifyTrustSetWithArgs(trustSet, args)
+	}
 
 	if tx.GetTransactionType() != data.PAYMENT {
 		return fmt.Errorf("Not a payment transaction")
@@ -43,9 +46,9 @@ func (b *Bridge) verifyTransactionWithArgs(tx data.Transaction, args *tokens.Bui
 func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error) {
 	log.Debug("Ripple DcrmSignTransaction")
 
-	tx, ok := rawTx.(*data.Payment)
+	tx, ok := rawTx.(data.Transaction)
 	if !ok {
-		return nil, "", fmt.Errorf("Type assertion error, transaction is not a payment")
+		return nil, "", fmt.Errorf("Type assertion error, transaction is not a ripple transaction")
 	}
 
 	err = b.verifyTransactionWithArgs(tx, args)
@@ -123,7 +126,7 @@ func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa
 
 // SignTransactionWithRippleKey sign tx with ripple key
 func (b *Bridge) SignTransactionWithRippleKey(rawTx interface{}, key rcrypto.Key, keyseq *uint32) (signTx interface{}, txHash string, err error) {
-	tx, ok := rawTx.(*data.Payment)
+	tx, ok := rawTx.(data.Transaction)
 	if !ok {
 		return nil, "", fmt.Errorf("sign transaction type assertion error")
 	}
@@ -162,15 +165,15 @@ func (b *Bridge) SignTransactionWithRippleKey(rawTx interface{}, key rcrypto.Key
 	if err != nil {
 		return nil, "", err
 	}
-	return stx, tx.Hash.String(), nil
+	return stx, tx.GetHash().String(), nil
 }
 
 // MakeSignedTransaction make signed transaction
 func (b *Bridge) MakeSignedTransaction(pubkey []byte, rsv string, transaction interface{}) (signedTransaction interface{}, err error) {
 	sig := rsvToSig(rsv, isEd25519Pubkey(pubkey))
-	tx, ok := transaction.(*data.Payment)
+	tx, ok := transaction.(data.Transaction)
 	if !ok {
-		return nil, fmt.Errorf("type assertion error, transaction is not a payment")
+		return nil, fmt.Errorf("type assertion error, transaction is not a ripple transaction")
 	}
 	*tx.GetSignature() = data.VariableLength(sig)
 	hash, _, err := data.Raw(tx)
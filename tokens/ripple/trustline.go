@@ -0,0 +1,244 @@
+package ripple
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/crypto"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/data"
+)
+
+// warn if trustline balance reach this ratio of limit
+var trustlineWarnRatio = big.NewRat(9, 10)
+
+// IsNonNativePair is non native (issued currency) pair
+func (b *Bridge) IsNonNativePair(pairID string) bool {
+	token := b.GetTokenConfig(pairID)
+	return token != nil && token.RippleExtra != nil && !token.RippleExtra.IsNative()
+}
+
+// GetTrustline get trustline of dcrm address of non native pair
+func (b *Bridge) GetTrustline(pairID string) (*data.AccountLine, error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if !b.IsNonNativePair(pairID) {
+		return nil, fmt.Errorf("pair '%v' is native, no trustline needed", pairID)
+	}
+	accl, err := b.GetAccountLine(token.RippleExtra.Currency, token.RippleExtra.Issuer, token.DcrmAddress)
+	if err != nil {
+		return nil, err
+	}
+	return accl, nil
+}
+
+// CheckTrustlines check trustlines of all non native pairs
+func (b *Bridge) CheckTrustlines() {
+	for _, pairID := range tokens.GetAllPairIDs() {
+		if b.IsNonNativePair(pairID) {
+			_ = b.CheckTrustline(pairID)
+		}
+	}
+}
+
+// CheckTrustline check trustline exist and warn if limit is nearly reached
+func (b *Bridge) CheckTrustline(pairID string) error {
+	if !b.IsNonNativePair(pairID) {
+		return nil
+	}
+	token := b.GetTokenConfig(pairID)
+	accl, err := b.GetTrustline(pairID)
+	if err != nil {
+		log.Error("[trustline] check trustline failed, please create it by admin command 'trustset'",
+			"pairID", pairID, "currency", token.RippleExtra.Currency, "issuer", token.RippleExtra.Issuer, "account", token.DcrmAddress, "err", err)
+		return err
+	}
+	// deposits of registered swapins are already in the trustline balance
+	balance := accl.Balance.Rat()
+	limit := accl.Limit.Rat()
+	ctx := []interface{}{
+		"pairID", pairID, "currency", token.RippleExtra.Currency, "issuer", token.RippleExtra.Issuer,
+		"account", token.DcrmAddress, "balance", accl.Balance.String(), "limit", accl.Limit.String(),
+	}
+	if limit.Sign() <= 0 {
+		log.Warn("[trustline] trustline limit is zero", ctx...)
+		return nil
+	}
+	ratio := new(big.Rat).Quo(balance, limit)
+	if ratio.Cmp(trustlineWarnRatio) >= 0 {
+		log.Warn("[trustline] trustline limit is nearly reached, pending swaps may fail", append(ctx, "ratio", ratio.FloatString(4))...)
+		return nil
+	}
+	log.Info("[trustline] check trustline success", ctx...)
+	return nil
+}
+
+// BuildTrustSetTransaction build trust set tx of dcrm address of non native pair
+func (b *Bridge) BuildTrustSetTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	pairID := args.PairID
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if !b.IsNonNativePair(pairID) {
+		return nil, fmt.Errorf("pair '%v' is native, no trustline needed", pairID)
+	}
+	if args.Extra == nil || args.Extra.RippleExtra == nil || args.Extra.RippleExtra.TrustLimit == nil {
+		return nil, errors.New("trust limit is not specified")
+	}
+	limitValue, err := data.NewValue(*args.Extra.RippleExtra.TrustLimit, false)
+	if err != nil || limitValue.IsNegative() {
+		return nil, fmt.Errorf("wrong trust limit '%v'", *args.Extra.RippleExtra.TrustLimit)
+	}
+	currency, exist := currencyMap[token.RippleExtra.Currency]
+	if !exist {
+		return nil, fmt.Errorf("non exist currency %v", token.RippleExtra.Currency)
+	}
+	issuer, exist := issuerMap[token.RippleExtra.Issuer]
+	if !exist {
+		return nil, fmt.Errorf("non exist issuer %v", token.RippleExtra.Issuer)
+	}
+
+	extra := args.Extra.RippleExtra
+	if extra.Sequence == nil {
+		extra.Sequence, err = b.GetSeq(nil, token.DcrmAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if extra.Fee == nil {
+		extra.Fee = new(int64)
+		*extra.Fee = defaultFee
+	}
+	args.Identifier = tokens.TrustSetIdentifier
+	args.SwapType = tokens.NoSwapType
+	args.From = token.DcrmAddress
+
+	pubkey := ImportPublicKey(common.FromHex(b.GetDcrmPublicKey(pairID)))
+	limit := &data.Amount{
+		Value:    limitValue,
+		Currency: currency,
+		Issuer:   *issuer,
+	}
+	rawtx, _, _ := NewUnsignedTrustSetTransaction(pubkey, nil, *extra.Sequence, limit, *extra.Fee)
+	if rawtx == nil {
+		return nil, errors.New("build trust set transaction failed")
+	}
+	return rawtx, nil
+}
+
+// VerifyTrustSetMsgHash rebuild trust set tx and verify msg hash
+func (b *Bridge) VerifyTrustSetMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
+	if args.Extra == nil || args.Extra.RippleExtra == nil ||
+		args.Extra.RippleExtra.Sequence == nil || args.Extra.RippleExtra.Fee == nil {
+		return errors.New("empty ripple extra")
+	}
+	rawTx, err := b.BuildTrustSetTransaction(args)
+	if err != nil {
+		return err
+	}
+	trustSet, ok := rawTx.(*data.TrustSet)
+	if !ok {
+		return errors.New("wrong trust set tx type")
+	}
+	err = b.verifyTrustSetWithArgs(trustSet, args)
+	if err != nil {
+		return err
+	}
+	return b.VerifyMsgHash(rawTx, msgHash)
+}
+
+// CreateTrustline build, dcrm sign and send trust set tx
+func (b *Bridge) CreateTrustline(pairID, trustLimit string) (txHash string, err error) {
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			PairID: pairID,
+		},
+		Extra: &tokens.AllExtras{
+			RippleExtra: &tokens.RippleExtra{
+				TrustLimit: &trustLimit,
+			},
+		},
+	}
+	rawTx, err := b.BuildTrustSetTransaction(args)
+	if err != nil {
+		return "", err
+	}
+	signedTx, txHash, err := b.DcrmSignTransaction(rawTx, args)
+	if err != nil {
+		return "", err
+	}
+	_, err = b.SendTransaction(signedTx)
+	if err != nil {
+		return "", err
+	}
+	log.Info("[trustline] send trust set tx success", "pairID", pairID, "limit", trustLimit, "txHash", txHash)
+	return txHash, nil
+}
+
+// NewUnsignedTrustSetTransaction build ripple trust set tx
+func NewUnsignedTrustSetTransaction(key crypto.Key, keyseq *uint32, txseq uint32, limit *data.Amount, fee int64) (data.Transaction, data.Hash256, []byte) {
+	trustSet := &data.TrustSet{
+		LimitAmount: *limit,
+	}
+	trustSet.TransactionType = data.TRUST_SET
+	trustSet.Flags = new(data.TransactionFlag)
+	*trustSet.Flags |= data.TxSetNoRipple
+
+	base := trustSet.GetBase()
+	base.Sequence = txseq
+
+	fei, err := data.NewNativeValue(fee)
+	if err != nil {
+		return nil, data.Hash256{}, nil
+	}
+	base.Fee = *fei
+
+	copy(base.Account[:], key.Id(keyseq))
+
+	trustSet.InitialiseForSigning()
+	copy(trustSet.GetPublicKey().Bytes(), key.Public(keyseq))
+	hash, msg, err := data.SigningHash(trustSet)
+	if err != nil {
+		log.Warn("Generate ripple trust set tx signing hash error", "error", err)
+		return nil, data.Hash256{}, nil
+	}
+	log.Info("Build unsigned trust set tx success", "signing hash", hash.String(), "blob", fmt.Sprintf("%X", msg))
+
+	return trustSet, hash, msg
+}
+
+func (b *Bridge) verifyTrustSetWithArgs(tx *data.TrustSet, args *tokens.BuildTxArgs) error {
+	if args.Identifier != tokens.TrustSetIdentifier {
+		return fmt.Errorf("[sign] trust set tx with wrong identifier '%v'", args.Identifier)
+	}
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	asset := tx.LimitAmount.Asset()
+	if asset.Currency != token.RippleExtra.Currency || asset.Issuer != token.RippleExtra.Issuer {
+		return fmt.Errorf("[sign] verify trust set currency failed")
+	}
+	return checkTrustLimit(args.PairID, token, tx.LimitAmount.Value)
+}
+
+// checkTrustLimit check trust limit not exceed the configed max trust limit
+func checkTrustLimit(pairID string, token *tokens.TokenConfig, limit *data.Value) error {
+	if token.RippleExtra.TrustLimit == "" {
+		return fmt.Errorf("[sign] trust limit of pair '%v' is not configed", pairID)
+	}
+	maxLimit, err := data.NewValue(token.RippleExtra.TrustLimit, false)
+	if err != nil {
+		return fmt.Errorf("[sign] wrong configed trust limit '%v'", token.RippleExtra.TrustLimit)
+	}
+	if limit == nil || limit.Rat().Cmp(maxLimit.Rat()) > 0 {
+		return fmt.Errorf("[sign] trust limit %v exceeds configed max trust limit %v", limit, maxLimit)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package ripple
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/data"
@@ -33,3 +35,20 @@ func parsePaths(s string) *data.PathSet {
 	}
 	return &ps
 }
+
+// minDestinationTag keep generated destination tags not too short
+const minDestinationTag = 100000
+
+// NewDestinationTag generate random destination tag
+func NewDestinationTag() (uint32, error) {
+	var buf [4]byte
+	for {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return 0, err
+		}
+		tag := binary.BigEndian.Uint32(buf[:])
+		if tag >= minDestinationTag {
+			return tag, nil
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/data"
 	"github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/websockets"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
 var errTxResultType = errors.New("tx type is not data.TxResult")
@@ -114,8 +115,11 @@ func (b *Bridge) verifySwapinTxWithPairID(pairID, txHash string, allowUnstable b
 	}
 
 	bind, ok := GetBindAddressFromMemos(payment)
+	if !ok && len(payment.Memos) == 0 && payment.DestinationTag != nil {
+		bind, ok = GetBindAddressFromDestinationTag(*payment.DestinationTag)
+	}
 	if !ok {
-		log.Debug("wrong memos", "memos", payment.Memos)
+		log.Debug("wrong memos", "memos", payment.Memos, "destinationTag", payment.DestinationTag)
 		return swapInfo, tokens.ErrWrongMemoBindAddress
 	}
 
@@ -175,6 +179,16 @@ func GetBindAddressFromMemos(tx data.Transaction) (bind string, ok bool) {
 	return "", false
 }
 
+// GetBindAddressFromDestinationTag get bind address of registered destination tag
+func GetBindAddressFromDestinationTag(tag uint32) (bind string, ok bool) {
+	bind = tools.GetDestinationTagBindAddress(tag)
+	if bind == "" {
+		log.Warn("Destination tag is not registered", "tag", tag)
+		return "", false
+	}
+	return bind, true
+}
+
 func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
 	token := b.GetTokenConfig(swapInfo.PairID)
 	if token == nil {
@@ -1,8 +1,10 @@
 package tokens
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
 
@@ -35,3 +37,63 @@ func RPCCallWithTimeout(timeout int, result interface{}, urls []string, method s
 	}
 	return WrapRPCQueryError(err, method, params...)
 }
+
+// QuorumCall call every url and return the result which the most urls (at least quorum) agree on.
+// digest select the content to compare of result (compare the whole result if it's nil).
+// disagreements between urls are logged as gateway integrity alerts.
+func QuorumCall(urls []string, quorum uint64, method string, call func(url string) (interface{}, error), digest func(result interface{}) interface{}) (interface{}, error) {
+	var (
+		err     error
+		results = make(map[string]interface{})
+		agrees  = make(map[string][]string) // digest -> urls
+	)
+	for _, url := range urls {
+		result, errf := call(url)
+		if errf != nil {
+			err = errf
+			continue
+		}
+		content := result
+		if digest != nil {
+			content = digest(result)
+		}
+		key, errf := json.Marshal(content)
+		if errf != nil {
+			err = errf
+			continue
+		}
+		results[string(key)] = result
+		agrees[string(key)] = append(agrees[string(key)], url)
+	}
+	if len(agrees) > 1 {
+		ctx := []interface{}{"method", method, "quorum", quorum}
+		for key, agreeURLs := range agrees {
+			ctx = append(ctx, "urls", agreeURLs, "result", key)
+		}
+		log.Warn("[integrity] gateway results mismatch", ctx...)
+	}
+	// select the largest group, conflicting groups of the same size are ambiguous
+	var (
+		bestKey  string
+		bestSize int
+		isTied   bool
+	)
+	for key, agreeURLs := range agrees {
+		switch size := len(agreeURLs); {
+		case size > bestSize:
+			bestKey, bestSize, isTied = key, size, false
+		case size == bestSize:
+			isTied = true
+		}
+	}
+	if isTied {
+		return nil, WrapRPCQueryError(fmt.Errorf("%w: conflicting results of %v urls each", ErrQuorumNotReached, bestSize), method)
+	}
+	if bestSize > 0 && uint64(bestSize) >= quorum {
+		return results[bestKey], nil
+	}
+	if err == nil {
+		err = fmt.Errorf("%w: quorum %v of %v is not reached", ErrQuorumNotReached, quorum, len(urls))
+	}
+	return nil, WrapRPCQueryError(err, method)
+}
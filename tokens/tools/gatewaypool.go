@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const healthWindowSize = 10 // recent probes used to calc error rate
+
+var (
+	srcGatewayPool = &GatewayPool{isSrc: true}
+	dstGatewayPool = &GatewayPool{isSrc: false}
+)
+
+// GatewayPool gateway pool of a chain with health status of every endpoint
+type GatewayPool struct {
+	isSrc     bool
+	endpoints []*EndpointHealth
+	ranked    []string // healthy api addresses of last check
+	lock      sync.RWMutex
+}
+
+// EndpointHealth health status of gateway endpoint
+type EndpointHealth struct {
+	URL             string    `json:"url"`
+	Height          uint64    `json:"height"`
+	HeightLag       uint64    `json:"heightLag"`
+	Latency         uint64    `json:"latency"` // milliseconds
+	ErrorRate       float64   `json:"errorRate"`
+	Failures        uint64    `json:"failures"` // consecutive failures
+	LastError       string    `json:"lastError,omitempty"`
+	LastCheck       time.Time `json:"lastCheck"`
+	Quarantined     bool      `json:"quarantined"`
+	QuarantineUntil time.Time `json:"quarantineUntil,omitempty"`
+	Backoff         uint64    `json:"backoff,omitempty"` // seconds
+
+	probes []bool // recent probe results, true is success
+}
+
+// GetGatewayPool get gateway pool of specified endpoint
+func GetGatewayPool(isSrc bool) *GatewayPool {
+	if isSrc {
+		return srcGatewayPool
+	}
+	return dstGatewayPool
+}
+
+// GetGatewayHealth get health status of all endpoints
+func GetGatewayHealth() map[string][]*EndpointHealth {
+	return map[string][]*EndpointHealth{
+		"src": srcGatewayPool.GetHealth(),
+		"dst": dstGatewayPool.GetHealth(),
+	}
+}
+
+// GetHealth get health status of endpoints
+func (p *GatewayPool) GetHealth() []*EndpointHealth {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	result := make([]*EndpointHealth, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		epCopy := *ep
+		epCopy.probes = nil
+		result = append(result, &epCopy)
+	}
+	return result
+}
+
+// init endpoints from the configed gateway api addresses (only once)
+func (p *GatewayPool) initEndpoints(apiAddresses []string) {
+	if len(p.endpoints) != 0 {
+		return
+	}
+	for _, url := range apiAddresses {
+		p.endpoints = append(p.endpoints, &EndpointHealth{URL: url})
+	}
+}
+
+// CheckHealth probe endpoints, quarantine unhealthy ones with backoff,
+// and return the healthy api addresses ordered by height and latency.
+func (p *GatewayPool) CheckHealth(bridge tokens.CrossChainBridge) (apiAddresses []string, maxHeight uint64) {
+	gateway := bridge.GetGatewayConfig()
+	cfg := gateway.HealthCheck
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.initEndpoints(gateway.APIAddress)
+
+	now := time.Now()
+	for i := len(p.endpoints); i > 0; i-- { // query in reverse order
+		ep := p.endpoints[i-1]
+		if ep.Quarantined && now.Before(ep.QuarantineUntil) {
+			continue // retry after backoff
+		}
+		if ep.Quarantined {
+			ep.probes = nil // judge by new probes after backoff
+		}
+		start := time.Now()
+		height, err := bridge.GetLatestBlockNumberOf(ep.URL)
+		ep.Latency = uint64(time.Since(start).Milliseconds())
+		ep.LastCheck = now
+		if err != nil {
+			ep.LastError = err.Error()
+		} else {
+			ep.LastError = ""
+			ep.Height = height
+		}
+		ep.addProbe(err == nil)
+		if err == nil && height > maxHeight {
+			maxHeight = height
+		}
+	}
+
+	healthy := make([]*EndpointHealth, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if !ep.LastCheck.Equal(now) {
+			continue // not probed as still quarantined
+		}
+		ep.HeightLag = 0
+		if maxHeight > ep.Height {
+			ep.HeightLag = maxHeight - ep.Height
+		}
+		if ep.isHealthy(cfg) {
+			if ep.Quarantined {
+				log.Info("[gateway] endpoint recovered", "isSrc", p.isSrc, "url", ep.URL)
+			}
+			ep.Quarantined = false
+			ep.Backoff = 0
+			healthy = append(healthy, ep)
+		} else {
+			ep.quarantine(cfg, now)
+			log.Warn("[gateway] quarantine unhealthy endpoint", "isSrc", p.isSrc, "url", ep.URL,
+				"height", ep.Height, "heightLag", ep.HeightLag, "latency", ep.Latency, "errorRate", ep.ErrorRate,
+				"failures", ep.Failures, "backoff", ep.Backoff, "lastError", ep.LastError)
+		}
+	}
+
+	if len(healthy) == 0 {
+		// never leave gateway empty, fallback to all endpoints
+		log.Warn("[gateway] no healthy endpoint, use all endpoints", "isSrc", p.isSrc)
+		healthy = append(healthy, p.endpoints...)
+	}
+
+	sort.SliceStable(healthy, func(i, j int) bool {
+		if healthy[i].Height != healthy[j].Height {
+			return healthy[i].Height > healthy[j].Height
+		}
+		return healthy[i].Latency < healthy[j].Latency
+	})
+
+	apiAddresses = make([]string, 0, len(healthy))
+	for _, ep := range healthy {
+		apiAddresses = append(apiAddresses, ep.URL)
+	}
+	p.ranked = apiAddresses
+	return apiAddresses, maxHeight
+}
+
+// GetAPIAddresses get healthy api addresses ordered by height and latency,
+// return the specified default ones if the health is not checked yet.
+func (p *GatewayPool) GetAPIAddresses(defaults []string) []string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if len(p.ranked) == 0 {
+		return defaults
+	}
+	return append([]string{}, p.ranked...)
+}
+
+func (ep *EndpointHealth) addProbe(success bool) {
+	ep.probes = append(ep.probes, success)
+	if len(ep.probes) > healthWindowSize {
+		ep.probes = ep.probes[len(ep.probes)-healthWindowSize:]
+	}
+	if success {
+		ep.Failures = 0
+	} else {
+		ep.Failures++
+	}
+	errCount := 0
+	for _, ok := range ep.probes {
+		if !ok {
+			errCount++
+		}
+	}
+	ep.ErrorRate = float64(errCount) / float64(len(ep.probes))
+}
+
+func (ep *EndpointHealth) isHealthy(cfg *tokens.GatewayHealthCheckConfig) bool {
+	if ep.LastError != "" {
+		return false
+	}
+	return ep.HeightLag <= cfg.GetMaxHeightLag() &&
+		ep.Latency <= cfg.GetMaxLatency() &&
+		ep.ErrorRate <= cfg.GetMaxErrorRate()
+}
+
+func (ep *EndpointHealth) quarantine(cfg *tokens.GatewayHealthCheckConfig, now time.Time) {
+	if ep.Backoff == 0 {
+		ep.Backoff = cfg.GetInitialBackoff()
+	} else {
+		ep.Backoff *= 2
+	}
+	if maxBackoff := cfg.GetMaxBackoff(); ep.Backoff > maxBackoff {
+		ep.Backoff = maxBackoff
+	}
+	ep.Quarantined = true
+	ep.QuarantineUntil = now.Add(time.Duration(ep.Backoff) * time.Second)
+}
@@ -187,6 +187,26 @@ func GetP2shBindAddress(p2shAddress string) (bindAddress string) {
 	return ""
 }
 
+// GetDestinationTagBindAddress get bind address of destination tag
+func GetDestinationTagBindAddress(tag uint32) (bindAddress string) {
+	if mongodb.HasClient() {
+		result, _ := mongodb.FindDestinationTag(tag)
+		if result != nil {
+			bindAddress = result.BindAddress
+		}
+		return bindAddress
+	}
+	var result tokens.DestinationTagInfo
+	for i := 0; i < retryRPCCount; i++ {
+		err := client.RPCPostWithTimeout(swapRPCTimeout, &result, params.ServerAPIAddress, "swap.GetDestinationTagInfo", tag)
+		if err == nil {
+			return result.BindAddress
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	return ""
+}
+
 // GetLatestScanHeight get latest scanned block height
 func GetLatestScanHeight(isSrc bool) uint64 {
 	if mongodb.HasClient() {
@@ -252,30 +272,18 @@ func IsAddressRegistered(address string) bool {
 	return false
 }
 
-// AdjustGatewayOrder adjust gateway order by block height
+// AdjustGatewayOrder check gateway health and adjust gateway order by block height and latency,
+// unhealthy gateways are quarantined and retried with backoff.
 func AdjustGatewayOrder(isSrc bool) {
-	// use block number as weight
-	var weightedAPIs WeightedStringSlice
 	bridge := tokens.GetCrossChainBridge(isSrc)
 	gateway := bridge.GetGatewayConfig()
-	length := len(gateway.APIAddress)
-	maxHeight := uint64(0)
-	for i := length; i > 0; i-- { // query in reverse order
-		apiAddress := gateway.APIAddress[i-1]
-		height, _ := bridge.GetLatestBlockNumberOf(apiAddress)
-		weightedAPIs = weightedAPIs.Add(apiAddress, height)
-		if height > maxHeight {
-			maxHeight = height
-		}
-	}
+	apiAddresses, maxHeight := GetGatewayPool(isSrc).CheckHealth(bridge)
 	tokens.CmpAndSetLatestBlockHeight(maxHeight, isSrc)
-	weightedAPIs.Reverse() // reverse as iter in reverse order in the above
-	weightedAPIs = weightedAPIs.Sort()
-	gateway.APIAddress = weightedAPIs.GetStrings()
+	gateway.APIAddress = apiAddresses
 	if isSrc {
-		log.Info("adjust source gateways", "result", weightedAPIs)
+		log.Info("adjust source gateways", "result", apiAddresses, "maxHeight", maxHeight)
 	} else {
-		log.Info("adjust dest gateways", "result", weightedAPIs)
+		log.Info("adjust dest gateways", "result", apiAddresses, "maxHeight", maxHeight)
 	}
 
 	if !params.EnableCheckBlockFork() {
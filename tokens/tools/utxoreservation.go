@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	// UnsentUtxoReservationLifetime reservation lifetime before the spend tx is sent
+	UnsentUtxoReservationLifetime = int64(3600) // seconds
+	// SentUtxoReservationLifetime reservation lifetime after the spend tx is sent
+	SentUtxoReservationLifetime = int64(5 * 24 * 3600) // seconds
+)
+
+func canReserveUtxo() bool {
+	return dcrm.IsSwapServer() && mongodb.HasClient()
+}
+
+// GetUtxoReserver get utxo reserver from build tx args
+func GetUtxoReserver(args *tokens.BuildTxArgs) string {
+	if args.Identifier == tokens.AggregateIdentifier {
+		return tokens.AggregateIdentifier
+	}
+	return fmt.Sprintf("%v:%v", args.SwapType.String(), mongodb.GetSwapKey(args.SwapID, args.PairID, args.Bind))
+}
+
+// IsUtxoReservedByOthers is utxo reserved by others (reservation is not expired)
+func IsUtxoReservedByOthers(txid string, vout uint32, reserver string) bool {
+	if !canReserveUtxo() {
+		return false
+	}
+	res, _ := mongodb.FindUtxoReservation(txid, vout)
+	if res == nil {
+		return false
+	}
+	if res.ExpireAt < time.Now().Unix() {
+		return false
+	}
+	return !strings.EqualFold(res.Reserver, reserver)
+}
+
+// ReserveUtxos reserve all utxos for the reserver, or none if any failed
+func ReserveUtxos(reserver, address string, points []*tokens.BtcOutPoint) error {
+	if !canReserveUtxo() {
+		return nil
+	}
+	expireAt := time.Now().Unix() + UnsentUtxoReservationLifetime
+	for i, point := range points {
+		err := mongodb.AddUtxoReservation(&mongodb.MgoUtxoReservation{
+			TxID:     point.Hash,
+			Vout:     point.Index,
+			Address:  address,
+			Reserver: reserver,
+			ExpireAt: expireAt,
+		})
+		if err != nil {
+			ReleaseUtxos(points[:i])
+			return fmt.Errorf("reserve utxo (%v, %v) failed: %w", point.Hash, point.Index, err)
+		}
+	}
+	log.Info("reserve utxos success", "reserver", reserver, "address", address, "count", len(points))
+	return nil
+}
+
+// SetUtxosSpendTx record the spend tx of reserved utxos
+func SetUtxosSpendTx(points []*tokens.BtcOutPoint, spendTx string) {
+	if !canReserveUtxo() {
+		return
+	}
+	expireAt := time.Now().Unix() + SentUtxoReservationLifetime
+	for _, point := range points {
+		_ = mongodb.UpdateUtxoReservationSpendTx(point.Hash, point.Index, spendTx, expireAt)
+	}
+}
+
+// ReleaseUtxos release reserved utxos
+func ReleaseUtxos(points []*tokens.BtcOutPoint) {
+	if !canReserveUtxo() {
+		return
+	}
+	for _, point := range points {
+		_ = mongodb.RemoveUtxoReservation(point.Hash, point.Index)
+	}
+}
@@ -82,6 +82,12 @@ type SwapInfo struct {
 	Bind       string     `json:"bind,omitempty"`
 	Identifier string     `json:"identifier,omitempty"`
 	Reswapping bool       `json:"reswapping,omitempty"`
+	TokenPrice float64    `json:"tokenPrice,omitempty"`
+
+	PairVersion uint64 `json:"pairVersion,omitempty"`
+
+	// pair config selected for the swap, use the current one if not specified
+	PairConfig *TokenPairConfig `json:"-"`
 }
 
 // IsSwapin is swapin type
@@ -89,6 +95,17 @@ func (s *SwapInfo) IsSwapin() bool {
 	return s.SwapType == SwapinType
 }
 
+// GetTokenConfig get token config used by the swap
+func (s *SwapInfo) GetTokenConfig(isSrc bool) *TokenConfig {
+	if s.PairConfig == nil {
+		return GetTokenConfig(s.PairID, isSrc)
+	}
+	if isSrc {
+		return s.PairConfig.SrcToken
+	}
+	return s.PairConfig.DestToken
+}
+
 // BuildTxArgs struct
 type BuildTxArgs struct {
 	SwapInfo    `json:"swapInfo,omitempty"`
@@ -154,12 +171,28 @@ type EthExtraArgs struct {
 	GasTipCap *big.Int `json:"gasTipCap,omitempty"`
 	GasFeeCap *big.Int `json:"gasFeeCap,omitempty"`
 	Nonce     *uint64  `json:"nonce,omitempty"`
+
+	GasCostFee     *big.Int `json:"gasCostFee,omitempty"`     // fee of gas cost in token amount
+	GasCostBaseFee *big.Int `json:"gasCostBaseFee,omitempty"` // base fee used to calc gas cost fee
+	NativePrice    float64  `json:"nativePrice,omitempty"`    // native price used to calc gas cost fee
+
+	GasDecision *GasDecision `json:"gasDecision,omitempty"`
+}
+
+// GasDecision decision of gas strategy
+type GasDecision struct {
+	Strategy    string   `json:"strategy"`
+	Value       *big.Int `json:"value"` // gas price of legacy tx, or gas tip cap of dynamic fee tx
+	BaseFee     *big.Int `json:"baseFee,omitempty"`
+	Percentile  float64  `json:"percentile,omitempty"`
+	MaxGasPrice *big.Int `json:"maxGasPrice,omitempty"`
 }
 
 // RippleExtra struct
 type RippleExtra struct {
-	Sequence *uint32 `json:"sequence,omitempty"`
-	Fee      *int64  `json:"fee,omitempty"`
+	Sequence   *uint32 `json:"sequence,omitempty"`
+	Fee        *int64  `json:"fee,omitempty"`
+	TrustLimit *string `json:"trustLimit,omitempty"`
 }
 
 // BtcOutPoint struct
@@ -175,6 +208,12 @@ type BtcExtraArgs struct {
 	PreviousOutPoints []*BtcOutPoint `json:"previousOutPoints,omitempty"`
 }
 
+// DestinationTagInfo struct
+type DestinationTagInfo struct {
+	BindAddress    string
+	DestinationTag uint32
+}
+
 // P2shAddressInfo struct
 type P2shAddressInfo struct {
 	BindAddress        string
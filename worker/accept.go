@@ -13,6 +13,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
 	mapset "github.com/deckarep/golang-set"
 )
 
@@ -179,6 +180,8 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 	case // these are situations we can not judge, ignore them or disagree immediately
 		errors.Is(err, tokens.ErrTxNotStable),
 		errors.Is(err, tokens.ErrTxNotFound),
+		errors.Is(err, tokens.ErrPairVersionMismatch),
+		errors.Is(err, errConfigBundleMismatch),
 		tokens.IsRPCQueryOrNotFoundError(err):
 		if isPendingInvalidAccept {
 			ctx = append(ctx, "err", err)
@@ -188,7 +191,8 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 	case // these we are sure are config problem, discard them or disagree immediately
 		errors.Is(err, errInitiatorMismatch),
 		errors.Is(err, tokens.ErrUnknownPairID),
-		errors.Is(err, tokens.ErrNoBtcBridge):
+		errors.Is(err, tokens.ErrNoBtcBridge),
+		errors.Is(err, tokens.ErrNoRippleBridge):
 		if isPendingInvalidAccept {
 			ctx = append(ctx, "err", err)
 			logWorker("accept", "discard sign", ctx...)
@@ -243,12 +247,17 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 	case params.GetIdentifier():
 	case params.GetReplaceIdentifier():
 	case tokens.AggregateIdentifier:
+	case tokens.TrustSetIdentifier:
+	case tokens.CancelNonceIdentifier:
 	default:
 		return args, errIdentifierMismatch
 	}
 	if !params.IsDcrmInitiator(signInfo.Account) {
 		return nil, errInitiatorMismatch
 	}
+	if err = checkConfigBundleMismatch(); err != nil {
+		return args, err
+	}
 
 	if args.Identifier == tokens.AggregateIdentifier {
 		if btc.BridgeInstance == nil {
@@ -262,6 +271,28 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 		return args, nil
 	}
 
+	if args.Identifier == tokens.TrustSetIdentifier {
+		rippleBridge, ok := tokens.SrcBridge.(*ripple.Bridge)
+		if !ok {
+			return args, tokens.ErrNoRippleBridge
+		}
+		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		err = rippleBridge.VerifyTrustSetMsgHash(msgHash, args)
+		if err != nil {
+			return args, err
+		}
+		return args, nil
+	}
+
+	if args.Identifier == tokens.CancelNonceIdentifier {
+		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		err = verifyCancelNonceMsgHash(msgHash, args)
+		if err != nil {
+			return args, err
+		}
+		return args, nil
+	}
+
 	logWorker("accept", "verifySignInfo", "keyID", signInfo.Key, "msgHash", msgHash, "msgContext", msgContext)
 	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
 		err = CheckAcceptRecord(args)
@@ -276,6 +307,23 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 	return args, nil
 }
 
+// cancel nonce tx is verified if it matches either of the nonce support bridges
+// (the chain id in the message hash decides which one)
+func verifyCancelNonceMsgHash(msgHash []string, args *tokens.BuildTxArgs) (err error) {
+	err = tokens.ErrMsgHashMismatch
+	for _, bridge := range []tokens.CrossChainBridge{tokens.DstBridge, tokens.SrcBridge} {
+		canceller, ok := bridge.(tokens.NonceCanceller)
+		if !ok {
+			continue
+		}
+		err = canceller.VerifyCancelNonceMsgHash(msgHash, args)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) error {
 	var srcBridge, dstBridge tokens.CrossChainBridge
 	switch args.SwapType {
@@ -289,8 +337,7 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		return fmt.Errorf("unknown swap type %v", args.SwapType)
 	}
 
-	tokenCfg := dstBridge.GetTokenConfig(args.PairID)
-	if tokenCfg == nil {
+	if dstBridge.GetTokenConfig(args.PairID) == nil {
 		return tokens.ErrUnknownPairID
 	}
 
@@ -303,20 +350,56 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		"bind", args.Bind,
 	}
 
+	err := tokens.CheckTokenPrice(args.PairID, args.IsSwapin(), args.TokenPrice)
+	if err != nil {
+		logWorkerError("accept", "check token price failed", err, ctx...)
+		return err
+	}
+
+	if verifier, ok := dstBridge.(tokens.GasPriceVerifier); ok {
+		err = verifier.VerifyGasPrice(args)
+		if err != nil {
+			logWorkerError("accept", "verify gas price failed", err, ctx...)
+			return err
+		}
+	}
+
 	swapInfo, err := verifySwapTransaction(srcBridge, args.PairID, args.SwapID, args.Bind, args.TxType)
 	if err != nil {
 		logWorkerError("accept", "verifySignInfo failed", err, ctx...)
 		return err
 	}
 
+	pairCfg := tokens.GetTokenPairConfigOfSwap(args.PairID, args.IsSwapin(), swapInfo.Height, swapInfo.Timestamp)
+	if pairCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if args.PairVersion != pairCfg.Version {
+		err = fmt.Errorf("%w: server %v, oracle %v", tokens.ErrPairVersionMismatch, args.PairVersion, pairCfg.Version)
+		logWorkerError("accept", "check pair config version failed", err, ctx...)
+		return err
+	}
+	tokenCfg := getSwapToTokenConfig(pairCfg, args.IsSwapin())
+
+	sender := tokenCfg.DcrmAddress
+	if args.From != "" {
+		if !tokenCfg.IsDcrmAddress(args.From) {
+			err = fmt.Errorf("sender %v is not in dcrm address pool", args.From)
+			logWorkerError("accept", "verify sender failed", err, ctx...)
+			return err
+		}
+		sender = args.From
+	}
+
 	buildTxArgs := &tokens.BuildTxArgs{
 		SwapInfo:    args.SwapInfo,
-		From:        tokenCfg.DcrmAddress,
+		From:        sender,
 		OriginFrom:  swapInfo.From,
 		OriginTxTo:  swapInfo.TxTo,
 		OriginValue: swapInfo.Value,
 		Extra:       args.Extra,
 	}
+	buildTxArgs.PairConfig = pairCfg
 	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
 	if err != nil {
 		logWorkerError("accept", "build raw tx failed", err, ctx...)
@@ -330,6 +413,10 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
 		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx)
 	}
+	if nonceSetter, ok := dstBridge.(tokens.NonceSetter); ok && args.GetTxNonce() > 0 {
+		// record signed nonce, cancel nonce tx is only allowed below it
+		nonceSetter.SetSenderNonce(sender, args.GetTxNonce()+1)
+	}
 	logWorker("accept", "verify message hash success", ctx...)
 	return nil
 }
@@ -104,13 +104,16 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 				continue // allow reswap old enough
 			}
 
+			// senders of address pool may have the same nonce,
+			// only the same sender and nonce is a replacement
 			txNonce := etx.GetAccountNonce()
 			argNonce := args.GetTxNonce()
-			if txNonce == argNonce {
+			isSameSender := etx.From != nil && strings.EqualFold(etx.From.String(), args.From)
+			if isSameSender && txNonce == argNonce {
 				continue // allow replace always
 			}
 
-			log.Warn("[accept] find already swapped tx in pool", "key", key, "value", value, "txNonce", txNonce, "argNonce", argNonce)
+			log.Warn("[accept] find already swapped tx in pool", "key", key, "value", value, "txNonce", txNonce, "argNonce", argNonce, "txFrom", etx.From, "argFrom", args.From)
 			alreadySwapped = true
 			break
 		}
@@ -3,14 +3,18 @@ package worker
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/fsnotify/fsnotify"
 )
 
-// AddTokenPairDynamically add token pair dynamically
+var activatePairConfigInterval = 10 * time.Second
+
+// AddTokenPairDynamically add, update or retire token pair dynamically
 func AddTokenPairDynamically() {
 	pairsDir := tokens.GetTokenPairsDir()
 	if pairsDir == "" {
@@ -46,6 +50,10 @@ func startWatcher(watch *fsnotify.Watcher) {
 		fsnotify.Create,
 		fsnotify.Write,
 	}
+	retireOps := []fsnotify.Op{
+		fsnotify.Remove,
+		fsnotify.Rename,
+	}
 
 	for {
 		select {
@@ -65,6 +73,15 @@ func startWatcher(watch *fsnotify.Watcher) {
 					break
 				}
 			}
+			for _, op := range retireOps {
+				if ev.Op&op == op {
+					err := retireTokenPair(ev.Name)
+					if err != nil {
+						log.Info("retireTokenPair error", "configFile", ev.Name, "err", err)
+					}
+					break
+				}
+			}
 		case werr, ok := <-watch.Errors:
 			if !ok {
 				continue
@@ -83,6 +100,14 @@ func addTokenPair(fileName string) error {
 	if fileStat == nil || fileStat.IsDir() || fileStat.Size() == 0 {
 		return nil
 	}
+	if pairID := tokens.GetPairIDOfConfigFile(fileName); pairID != "" && tokens.IsTokenPairExist(pairID) {
+		pairConfig, err := tokens.UpdatePairConfig(fileName)
+		if err != nil {
+			return err
+		}
+		log.Info("updateTokenPair success", "configFile", fileName, "pairID", pairConfig.PairID, "version", pairConfig.Version)
+		return nil
+	}
 	pairConfig, err := tokens.AddPairConfig(fileName)
 	if err != nil {
 		return err
@@ -90,3 +115,66 @@ func addTokenPair(fileName string) error {
 	log.Info("addTokenPair success", "configFile", fileName, "pairID", pairConfig.PairID)
 	return nil
 }
+
+func retireTokenPair(fileName string) error {
+	pairID := tokens.GetPairIDOfConfigFile(fileName)
+	if pairID == "" {
+		return nil
+	}
+	// ignore if file is recreated (eg. some editors save file by rename)
+	if fileStat, _ := os.Stat(fileName); fileStat != nil {
+		return nil
+	}
+	pairConfig, err := tokens.RetirePairConfig(pairID)
+	if err != nil {
+		return err
+	}
+	log.Info("retireTokenPair success", "configFile", fileName, "pairID", pairConfig.PairID)
+	return nil
+}
+
+// StartActivatePairConfigJob activate pending pair configs
+func StartActivatePairConfigJob(isServer bool) {
+	mongodb.MgoWaitGroup.Add(1)
+	go loopActivatePairConfigs(isServer)
+}
+
+func loopActivatePairConfigs(isServer bool) {
+	defer mongodb.MgoWaitGroup.Done()
+	for {
+		if utils.IsCleanuping() {
+			return
+		}
+		activatePairConfigs(isServer)
+		restInJob(activatePairConfigInterval)
+	}
+}
+
+func activatePairConfigs(isServer bool) {
+	needHeight := false
+	for _, pairID := range tokens.GetAllPairIDs() {
+		pending := tokens.GetPendingPairConfig(pairID)
+		if pending == nil {
+			continue
+		}
+		if pending.ActivateHeight > 0 {
+			needHeight = true
+			break
+		}
+	}
+	var height uint64
+	if needHeight {
+		var err error
+		height, err = tokens.SrcBridge.GetLatestBlockNumber()
+		if err != nil {
+			logWorkerWarn("activatepair", "get latest block number failed", "err", err)
+			return
+		}
+	}
+	for _, pairCfg := range tokens.ActivatePendingPairConfigs(height) {
+		logWorker("activatepair", "activate pair config", "pairID", pairCfg.PairID, "version", pairCfg.Version, "height", height)
+		if isServer {
+			AddSwapJob(pairCfg)
+		}
+	}
+}
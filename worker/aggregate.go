@@ -5,8 +5,10 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
 var (
@@ -73,6 +75,10 @@ func findUtxosAndAggregate(addr string) {
 		if isUtxoExist(utxo) {
 			continue
 		}
+		if tools.IsUtxoReservedByOthers(*utxo.Txid, *utxo.Vout, tokens.AggregateIdentifier) {
+			logWorkerTrace("aggregate", "ignore reserved utxo", "address", addr, "utxo", utxo.String())
+			continue
+		}
 		outspend, err := btc.BridgeInstance.GetOutspend(*utxo.Txid, *utxo.Vout)
 		if err != nil {
 			logWorkerError("aggregate", "get out spend failed", err, "address", addr, "utxo", utxo.String())
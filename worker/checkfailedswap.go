@@ -98,6 +98,11 @@ func checkFailedSwap(swap *mongodb.MgoSwapResult, isSwapin bool) error {
 
 	txStatus := getSwapTxStatus(resBridge, swap)
 	if txStatus != nil && txStatus.IsSwapTxOnChainAndFailed(tokenCfg) {
+		if swap.Memo == "" {
+			if memo := getSwapTxRevertReason(resBridge, swap.SwapTx); memo != "" {
+				return markSwapResultFailed(txid, pairID, bind, isSwapin, memo)
+			}
+		}
 		return nil
 	}
 
@@ -109,7 +114,7 @@ func checkFailedSwap(swap *mongodb.MgoSwapResult, isSwapin bool) error {
 		return markSwapResultStable(txid, pairID, bind, isSwapin)
 	}
 
-	nonce, err := nonceSetter.GetPoolNonce(tokenCfg.DcrmAddress, "latest")
+	nonce, err := nonceSetter.GetPoolNonce(getSwapSender(tokenCfg, swap), "latest")
 	if err != nil {
 		return errGetNonceFailed
 	}
@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -19,6 +20,8 @@ type MatchTx struct {
 	SwapValue  string
 	SwapType   tokens.SwapType
 	SwapNonce  uint64
+	TokenPrice float64
+	SwapFrom   string
 }
 
 func getSwapType(isSwapin bool) tokens.SwapType {
@@ -77,6 +80,8 @@ func updateSwapResult(txid, pairID, bind string, mtx *MatchTx) (err error) {
 	if mtx.SwapHeight == 0 {
 		updates.SwapValue = mtx.SwapValue
 		updates.SwapNonce = mtx.SwapNonce
+		updates.TokenPrice = mtx.TokenPrice
+		updates.SwapFrom = mtx.SwapFrom
 		updates.SwapHeight = 0
 		updates.SwapTime = 0
 		if mtx.SwapTx != "" {
@@ -209,19 +214,48 @@ func markSwapResultStable(txid, pairID, bind string, isSwapin bool) (err error)
 	return err
 }
 
-func markSwapResultFailed(txid, pairID, bind string, isSwapin bool) (err error) {
+func markSwapResultFailed(txid, pairID, bind string, isSwapin bool, memo string) (err error) {
 	status := mongodb.MatchTxFailed
 	timestamp := now()
-	memo := "" // unchange
 	err = mongodb.UpdateSwapResultStatus(isSwapin, txid, pairID, bind, status, timestamp, memo)
 	if err != nil {
-		logWorkerError("stable", "markSwapResultFailed", err, "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin)
+		logWorkerError("stable", "markSwapResultFailed", err, "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "memo", memo)
 	} else {
-		logWorker("stable", "markSwapResultFailed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin)
+		logWorker("stable", "markSwapResultFailed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "memo", memo)
 	}
 	return err
 }
 
+// getSwapToTokenConfig get token config of the chain where swap tx is sent
+func getSwapToTokenConfig(pairCfg *tokens.TokenPairConfig, isSwapin bool) *tokens.TokenConfig {
+	if isSwapin {
+		return pairCfg.DestToken
+	}
+	return pairCfg.SrcToken
+}
+
+// unknownRevertReason is the memo of failed swaptx whose revert reason
+// can not be got, to prevent querying it again and again
+const unknownRevertReason = "revert: unknown reason"
+
+// getSwapTxRevertReason get revert reason of on chain failed swaptx,
+// return empty if it can not be got for now (eg. rpc failed) to retry later
+func getSwapTxRevertReason(resBridge tokens.CrossChainBridge, swapTx string) string {
+	getter, ok := resBridge.(tokens.RevertReasonGetter)
+	if !ok {
+		return ""
+	}
+	reason, err := getter.GetTxRevertReason(swapTx)
+	if err != nil {
+		logWorkerWarn("stable", "get swaptx revert reason failed", "swaptx", swapTx, "err", err)
+		if errors.Is(err, tokens.ErrRevertReasonNotFound) {
+			return unknownRevertReason
+		}
+		return ""
+	}
+	return "revert: " + reason
+}
+
 func verifySwapTransaction(bridge tokens.CrossChainBridge, pairID, txid, bind string, swapTxType tokens.SwapTxType) (swapInfo *tokens.TxSwapInfo, err error) {
 	switch swapTxType {
 	case tokens.P2shSwapinTx:
@@ -260,6 +294,7 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 	if txHash != "" {
 		addSwapHistory(isSwapin, txid, bind)
 		_ = mongodb.AddSwapHistory(isSwapin, txid, bind, txHash)
+		addSwapNonceLedger(bridge, args, txHash)
 	}
 	if err != nil {
 		logWorkerError("sendtx", "send tx failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "txHash", txHash, "swapNonce", swapNonce)
@@ -268,7 +303,11 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 
 	nonceSetter, ok := bridge.(tokens.NonceSetter)
 	if ok && nonceSetter != nil {
-		nonceSetter.SetNonce(pairID, swapNonce+1) // increase for next usage
+		if args.From != "" {
+			nonceSetter.SetSenderNonce(args.From, swapNonce+1) // increase for next usage
+		} else {
+			nonceSetter.SetNonce(pairID, swapNonce+1) // increase for next usage
+		}
 	}
 
 	go sendTxLoopUntilSuccess(bridge, txHash, signedTx, args)
@@ -276,6 +315,29 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 	return txHash, nil
 }
 
+func addSwapNonceLedger(bridge tokens.CrossChainBridge, args *tokens.BuildTxArgs, txHash string) {
+	if _, ok := bridge.(tokens.NonceSetter); !ok {
+		return
+	}
+	address := args.From
+	if address == "" {
+		tokenCfg := bridge.GetTokenConfig(args.PairID)
+		if tokenCfg == nil {
+			return
+		}
+		address = tokenCfg.DcrmAddress
+	}
+	_ = mongodb.AddSwapNonceLedger(&mongodb.MgoSwapNonceLedger{
+		Address:  address,
+		IsSwapin: args.SwapType == tokens.SwapinType,
+		Nonce:    args.GetTxNonce(),
+		PairID:   args.PairID,
+		TxID:     args.SwapID,
+		Bind:     args.Bind,
+		SwapTx:   txHash,
+	})
+}
+
 func sendTxLoopUntilSuccess(bridge tokens.CrossChainBridge, txHash string, signedTx interface{}, args *tokens.BuildTxArgs) {
 	severCfg := params.GetServerConfig()
 	sendTxLoopCount := severCfg.SendTxLoopCount
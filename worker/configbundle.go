@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+)
+
+var (
+	checkConfigBundleInterval = 300 * time.Second
+
+	// 1 if local config mismatch the published config bundle
+	configBundleMismatch int32
+
+	errConfigBundleMismatch = errors.New("local config mismatch the config bundle of server")
+)
+
+// StartCheckConfigBundleJob oracle check local config with config bundle published by server
+func StartCheckConfigBundleJob() {
+	if params.GetOracleConfig() == nil || !params.HasAdmin() || params.ServerAPIAddress == "" {
+		return
+	}
+	utils.TopWaitGroup.Add(1)
+	go loopCheckConfigBundle()
+}
+
+func loopCheckConfigBundle() {
+	defer utils.TopWaitGroup.Done()
+	logWorker("configbundle", "start check config bundle job")
+	for {
+		if utils.IsCleanuping() {
+			logWorker("configbundle", "stop check config bundle job")
+			return
+		}
+		err := checkConfigBundle()
+		if err != nil {
+			// keep the last known state, not let failures bypass the refusing
+			logWorkerError("configbundle", "check config bundle failed", err, "mismatch", atomic.LoadInt32(&configBundleMismatch) != 0)
+		}
+		restInJob(checkConfigBundleInterval)
+	}
+}
+
+func checkConfigBundle() error {
+	var signed params.SignedConfigBundle
+	err := client.RPCPostWithTimeout(60, &signed, params.ServerAPIAddress, "swap.GetConfigBundle")
+	if err != nil {
+		return err
+	}
+	if signed.Bundle == nil {
+		return errors.New("empty config bundle")
+	}
+	err = verifyConfigBundle(&signed)
+	if err != nil {
+		return err
+	}
+	mismatches := params.BuildConfigBundle().Diff(signed.Bundle)
+	if len(mismatches) == 0 {
+		if atomic.SwapInt32(&configBundleMismatch, 0) != 0 {
+			logWorker("configbundle", "local config match config bundle again")
+		}
+		return nil
+	}
+	atomic.StoreInt32(&configBundleMismatch, 1)
+	logWorkerWarn("configbundle", "ALERT: local config mismatch config bundle of server",
+		"mismatches", strings.Join(mismatches, ", "), "refuse", params.GetOracleConfig().RefuseOnConfigMismatch)
+	return nil
+}
+
+func verifyConfigBundle(signed *params.SignedConfigBundle) error {
+	tx, err := admin.DecodeTransaction(signed.Signature)
+	if err != nil {
+		return err
+	}
+	sender, args, err := admin.VerifyTransactionWithoutExpire(tx)
+	if err != nil {
+		return err
+	}
+	if !params.IsAdmin(sender.String()) {
+		return fmt.Errorf("config bundle signer %v is not admin", sender.String())
+	}
+	if args.Method != "publishconfig" || len(args.Params) != 1 {
+		return fmt.Errorf("wrong config bundle signature of method %v", args.Method)
+	}
+	bundleHash, err := signed.Bundle.Hash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(args.Params[0], bundleHash.String()) {
+		return fmt.Errorf("config bundle hash mismatch, signed %v, received %v", args.Params[0], bundleHash.String())
+	}
+	return nil
+}
+
+func checkConfigBundleMismatch() error {
+	if atomic.LoadInt32(&configBundleMismatch) != 0 && params.GetOracleConfig().RefuseOnConfigMismatch {
+		return errConfigBundleMismatch
+	}
+	return nil
+}
@@ -5,6 +5,8 @@
 //		verify registered swaps.
 //	swap
 //		build swaptx, mpc sign the tx, and send the tx to blockchain.
+//	recovery
+//		resume swaps interrupted during signing or sending by the write ahead sign logs on startup.
 //	accept
 //		the `oracle` node do the accept job, agree or disagree the signing after verifying by oralce itself.
 //	stable
@@ -13,5 +15,7 @@
 //		replace swap with the same tx nonce value when the sent swaptx is not packed into block because of lack fee or other reasons.
 //	passbigvalue
 //		pass big value swap if the swap value is too large.
+//	noncemanager
+//		detect nonce gaps of mpc address, and fill them by replacing the swap or sending cancel nonce tx.
 // Most the above jobs is assigned to the `server` node, the `oracle` node mainly do the `accept` job.
 package worker
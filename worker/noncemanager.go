@@ -0,0 +1,255 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	checkNonceGapInterval = 60 * time.Second
+	waitTimeToFillGap     = int64(300) // seconds
+	maxNonceGapScanCount  = uint64(10)
+
+	// key is address + isswapin + nonce, value is first seen time of the gap
+	nonceGapFirstSeen     = make(map[string]int64)
+	nonceGapFirstSeenLock sync.Mutex
+)
+
+// StartNonceManagerJob detect and fill nonce gaps of dcrm addresses
+func StartNonceManagerJob() {
+	if tokens.DstNonceSetter != nil {
+		if _, ok := tokens.DstBridge.(tokens.NonceCanceller); ok {
+			mongodb.MgoWaitGroup.Add(1)
+			go loopCheckNonceGaps(true)
+		}
+	}
+
+	if tokens.SrcNonceSetter != nil {
+		if _, ok := tokens.SrcBridge.(tokens.NonceCanceller); ok {
+			mongodb.MgoWaitGroup.Add(1)
+			go loopCheckNonceGaps(false)
+		}
+	}
+}
+
+func loopCheckNonceGaps(isSwapin bool) {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("noncemanager", "start nonce manager job", "isSwapin", isSwapin)
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			logWorker("noncemanager", "stop nonce manager job", "isSwapin", isSwapin)
+			return
+		}
+		logWorkerTrace("noncemanager", "start check nonce gaps", "isSwapin", isSwapin, "loop", loop)
+		checkNonceGaps(isSwapin)
+		restInJob(checkNonceGapInterval)
+	}
+}
+
+func checkNonceGaps(isSwapin bool) {
+	bridge := tokens.GetCrossChainBridge(!isSwapin)
+	checked := make(map[string]struct{})
+	for _, pairID := range tokens.GetAllPairIDs() {
+		tokenCfg := bridge.GetTokenConfig(pairID)
+		if tokenCfg == nil {
+			continue
+		}
+		for _, dcrmAddress := range tokenCfg.GetDcrmAddresses() {
+			address := strings.ToLower(dcrmAddress)
+			if _, exist := checked[address]; exist {
+				continue
+			}
+			checked[address] = struct{}{}
+			checkNonceGapsOfAddress(bridge, pairID, address, isSwapin)
+		}
+	}
+}
+
+func checkNonceGapsOfAddress(bridge tokens.CrossChainBridge, pairID, address string, isSwapin bool) {
+	nonceSetter := bridge.(tokens.NonceSetter)
+	latest, err := nonceSetter.GetPoolNonce(address, "latest")
+	if err != nil {
+		logWorkerError("noncemanager", "get latest nonce failed", err, "address", address, "isSwapin", isSwapin)
+		return
+	}
+	_ = mongodb.ConfirmSwapNonceLedgers(address, isSwapin, latest)
+	checkCancellingLedgers(bridge, address, isSwapin, latest)
+
+	local := nonceSetter.AdjustSenderNonce(address, 0)
+	end := local
+	if end > latest+maxNonceGapScanCount {
+		end = latest + maxNonceGapScanCount
+	}
+	now := time.Now().Unix()
+	for nonce := latest; nonce < end; nonce++ {
+		key := getNonceGapKey(address, isSwapin, nonce)
+		ledger, _ := mongodb.FindSwapNonceLedger(address, isSwapin, nonce)
+		if ledger != nil && ledger.Status == mongodb.NonceLedgerCancelling {
+			// wait for the cancel nonce tx, otherwise it's dropped and refill again
+			if _, err = bridge.GetTransaction(ledger.CancelTx); err == nil {
+				removeNonceGap(key)
+				continue
+			}
+		}
+		if ledger != nil && ledger.SwapTx != "" {
+			if _, err = bridge.GetTransaction(ledger.SwapTx); err == nil {
+				removeNonceGap(key)
+				continue
+			}
+		}
+		firstSeen := markNonceGap(key, now)
+		if firstSeen+waitTimeToFillGap > now {
+			logWorkerWarn("noncemanager", "found nonce gap", "address", address, "nonce", nonce, "isSwapin", isSwapin, "latest", latest, "local", local)
+			continue
+		}
+		err = fillNonceGap(bridge, pairID, address, nonce, ledger, isSwapin)
+		if err != nil {
+			logWorkerError("noncemanager", "fill nonce gap failed", err, "address", address, "nonce", nonce, "isSwapin", isSwapin)
+			continue
+		}
+		removeNonceGap(key)
+	}
+}
+
+func fillNonceGap(bridge tokens.CrossChainBridge, pairID, address string, nonce uint64, ledger *mongodb.MgoSwapNonceLedger, isSwapin bool) error {
+	if ledger != nil && ledger.TxID != "" {
+		res, err := mongodb.FindSwapResult(isSwapin, ledger.TxID, ledger.PairID, ledger.Bind)
+		if err == nil && res.Status == mongodb.MatchTxNotStable && res.SwapHeight == 0 && res.SwapNonce == nonce {
+			txHash, errf := replaceSwap(ledger.TxID, ledger.PairID, ledger.Bind, "", isSwapin, false)
+			if errf == nil {
+				logWorker("noncemanager", "refill nonce gap by replace swap success", "address", address, "nonce", nonce, "isSwapin", isSwapin, "txid", ledger.TxID, "swaptx", txHash)
+				return mongodb.UpdateSwapNonceLedgerStatus(address, isSwapin, nonce, mongodb.NonceLedgerRefilled, txHash)
+			}
+			logWorkerWarn("noncemanager", "refill nonce gap by replace swap failed, try cancel nonce", "address", address, "nonce", nonce, "isSwapin", isSwapin, "txid", ledger.TxID, "err", errf)
+		}
+	}
+
+	txHash, err := sendCancelNonceTx(bridge, pairID, address, nonce)
+	if err != nil {
+		return err
+	}
+	logWorker("noncemanager", "fill nonce gap by cancel nonce tx success", "address", address, "nonce", nonce, "isSwapin", isSwapin, "canceltx", txHash)
+	if ledger != nil {
+		// the swap is reset after the cancel nonce tx is confirmed
+		return mongodb.UpdateSwapNonceLedgerCancelling(address, isSwapin, nonce, txHash)
+	}
+	return mongodb.AddSwapNonceLedger(&mongodb.MgoSwapNonceLedger{
+		Address:  address,
+		IsSwapin: isSwapin,
+		Nonce:    nonce,
+		PairID:   pairID,
+		SwapTx:   txHash,
+		CancelTx: txHash,
+		Status:   mongodb.NonceLedgerCancelled,
+	})
+}
+
+// checkCancellingLedgers decide which tx is mined at the nonce of cancelling ledgers,
+// the original swap tx may be pending on other nodes and mined instead of the cancel nonce tx.
+func checkCancellingLedgers(bridge tokens.CrossChainBridge, address string, isSwapin bool, latest uint64) {
+	ledgers, err := mongodb.FindSwapNonceLedgersWithStatus(address, isSwapin, mongodb.NonceLedgerCancelling)
+	if err != nil {
+		return
+	}
+	confirmations := *bridge.GetChainConfig().Confirmations
+	for _, ledger := range ledgers {
+		if ledger.Nonce >= latest {
+			continue // nonce is not used yet
+		}
+		nonce := ledger.Nonce
+		if isTxConfirmed(bridge, ledger.CancelTx, confirmations) {
+			if ledger.TxID != "" {
+				resetCancelledSwap(ledger, nonce, isSwapin)
+			}
+			_ = mongodb.UpdateSwapNonceLedgerStatus(address, isSwapin, nonce, mongodb.NonceLedgerCancelled, "")
+			continue
+		}
+		if ledger.SwapTx != "" && isTxConfirmed(bridge, ledger.SwapTx, confirmations) {
+			logWorkerWarn("noncemanager", "swap tx is mined instead of cancel nonce tx", "address", address, "nonce", nonce, "isSwapin", isSwapin, "txid", ledger.TxID, "swaptx", ledger.SwapTx, "canceltx", ledger.CancelTx)
+			_ = mongodb.UpdateSwapNonceLedgerStatus(address, isSwapin, nonce, mongodb.NonceLedgerConfirmed, "")
+			continue
+		}
+		logWorkerTrace("noncemanager", "wait cancel nonce tx to be confirmed", "address", address, "nonce", nonce, "isSwapin", isSwapin, "canceltx", ledger.CancelTx)
+	}
+}
+
+func isTxConfirmed(bridge tokens.CrossChainBridge, txHash string, confirmations uint64) bool {
+	if txHash == "" {
+		return false
+	}
+	txStatus, err := bridge.GetTransactionStatus(txHash)
+	return err == nil && txStatus.BlockHeight > 0 && txStatus.Confirmations >= confirmations
+}
+
+// the swap tx of the ledger is replaced by the confirmed cancel nonce tx and will never
+// be mined, reset the swap to let it be rebuilt with a new nonce
+func resetCancelledSwap(ledger *mongodb.MgoSwapNonceLedger, nonce uint64, isSwapin bool) {
+	txid, pairID, bind := ledger.TxID, ledger.PairID, ledger.Bind
+	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
+	if err != nil || res.Status != mongodb.MatchTxNotStable || res.SwapHeight != 0 || res.SwapNonce != nonce {
+		return
+	}
+	err = mongodb.UpdateSwapResultStatus(isSwapin, txid, pairID, bind, mongodb.Reswapping, now(), "")
+	if err == nil {
+		err = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxNotSwapped, now(), "")
+	}
+	if err != nil {
+		logWorkerError("noncemanager", "reset cancelled swap failed", err, "txid", txid, "pairID", pairID, "bind", bind, "nonce", nonce, "isSwapin", isSwapin)
+		return
+	}
+	logWorker("noncemanager", "reset cancelled swap success", "txid", txid, "pairID", pairID, "bind", bind, "nonce", nonce, "isSwapin", isSwapin)
+}
+
+func sendCancelNonceTx(bridge tokens.CrossChainBridge, pairID, address string, nonce uint64) (txHash string, err error) {
+	canceller, ok := bridge.(tokens.NonceCanceller)
+	if !ok {
+		return "", errNotNonceSupport
+	}
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			PairID: pairID,
+		},
+		From: address,
+		Extra: &tokens.AllExtras{
+			EthExtra: &tokens.EthExtraArgs{
+				Nonce: &nonce,
+			},
+		},
+	}
+	rawTx, err := canceller.BuildCancelNonceTx(args)
+	if err != nil {
+		return "", err
+	}
+	signedTx, _, err := signSwapTransaction(bridge, rawTx, args)
+	if err != nil {
+		return "", err
+	}
+	return bridge.SendTransaction(signedTx)
+}
+
+func getNonceGapKey(address string, isSwapin bool, nonce uint64) string {
+	return fmt.Sprintf("%v:%v:%v", address, isSwapin, nonce)
+}
+
+func markNonceGap(key string, now int64) (firstSeen int64) {
+	nonceGapFirstSeenLock.Lock()
+	defer nonceGapFirstSeenLock.Unlock()
+	firstSeen, exist := nonceGapFirstSeen[key]
+	if !exist {
+		firstSeen = now
+		nonceGapFirstSeen[key] = firstSeen
+	}
+	return firstSeen
+}
+
+func removeNonceGap(key string) {
+	nonceGapFirstSeenLock.Lock()
+	defer nonceGapFirstSeenLock.Unlock()
+	delete(nonceGapFirstSeen, key)
+}
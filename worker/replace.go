@@ -110,6 +110,15 @@ func getReplaceConfigs(isSwapin bool) (waitTimeToReplace int64, maxReplaceCount
 	return waitTimeToReplace, maxReplaceCount
 }
 
+// GetMaxReplaceCount get max replace count, swaps replaced more times are left to admin
+func GetMaxReplaceCount(isSwapin bool) int {
+	_, maxReplaceCount := getReplaceConfigs(isSwapin)
+	if maxReplaceCount == 0 {
+		maxReplaceCount = defMaxReplaceCount
+	}
+	return maxReplaceCount
+}
+
 func processReplaceSwap(swap *mongodb.MgoSwapResult, isSwapin bool) {
 	if swap.SwapNonce == 0 || swap.SwapHeight != 0 {
 		return
@@ -117,14 +126,11 @@ func processReplaceSwap(swap *mongodb.MgoSwapResult, isSwapin bool) {
 	if swap.Status != mongodb.MatchTxNotStable {
 		return
 	}
-	waitTimeToReplace, maxReplaceCount := getReplaceConfigs(isSwapin)
+	waitTimeToReplace, _ := getReplaceConfigs(isSwapin)
 	if waitTimeToReplace == 0 {
 		waitTimeToReplace = defWaitTimeToReplace
 	}
-	if maxReplaceCount == 0 {
-		maxReplaceCount = defMaxReplaceCount
-	}
-	if len(swap.OldSwapTxs) > maxReplaceCount {
+	if len(swap.OldSwapTxs) > GetMaxReplaceCount(isSwapin) {
 		return
 	}
 	if getSepTimeInFind(waitTimeToReplace) < swap.Timestamp {
@@ -145,14 +151,14 @@ func dispatchReplaceTask(swap *mongodb.MgoSwapResult) {
 	pairCfg := tokens.GetTokenPairConfig(pairID)
 	isSwapin := tokens.SwapType(swap.SwapType) == tokens.SwapinType
 	if isSwapin {
-		swapinDcrmAddr := strings.ToLower(pairCfg.DestToken.DcrmAddress)
+		swapinDcrmAddr := strings.ToLower(getSwapSender(pairCfg.DestToken, swap))
 		if _, exist := swapinReplaceChanMap[swapinDcrmAddr]; !exist {
 			swapinReplaceChanMap[swapinDcrmAddr] = make(chan *mongodb.MgoSwapResult, swapChanSize)
 			go processReplaceSwapTask(swapinReplaceChanMap[swapinDcrmAddr])
 		}
 		swapinReplaceChanMap[swapinDcrmAddr] <- swap
 	} else {
-		swapoutDcrmAddr := strings.ToLower(pairCfg.SrcToken.DcrmAddress)
+		swapoutDcrmAddr := strings.ToLower(getSwapSender(pairCfg.SrcToken, swap))
 		if _, exist := swapoutReplaceChanMap[swapoutDcrmAddr]; !exist {
 			swapoutReplaceChanMap[swapoutDcrmAddr] = make(chan *mongodb.MgoSwapResult, swapChanSize)
 			go processReplaceSwapTask(swapoutReplaceChanMap[swapoutDcrmAddr])
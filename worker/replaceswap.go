@@ -82,7 +82,7 @@ func checkIfSwapNonceHasPassed(bridge tokens.CrossChainBridge, res *mongodb.MgoS
 	if tokenCfg == nil {
 		return fmt.Errorf("no token config for pairID '%v'", pairID)
 	}
-	nonce, err := nonceSetter.GetPoolNonce(tokenCfg.DcrmAddress, "latest")
+	nonce, err := nonceSetter.GetPoolNonce(getSwapSender(tokenCfg, res), "latest")
 	if err != nil {
 		return errGetNonceFailed
 	}
@@ -120,7 +120,7 @@ func checkIfSwapNonceHasPassed(bridge tokens.CrossChainBridge, res *mongodb.MgoS
 				return errors.New("forbid mark reswaping result to failed status")
 			}
 			logWorkerWarn(iden, "mark swap result failed with nonce passed", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "swaptime", res.Timestamp, "nowtime", now(), "swapNonce", res.SwapNonce, "latestNonce", nonce)
-			_ = markSwapResultFailed(txid, pairID, bind, isSwapin)
+			_ = markSwapResultFailed(txid, pairID, bind, isSwapin, "")
 		}
 		if isReplace {
 			return errSwapNoncePassed
@@ -157,7 +157,11 @@ func replaceSwap(txid, pairID, bind, gasPriceStr string, isSwapin, isManual bool
 	}
 
 	bridge := tokens.GetCrossChainBridge(!isSwapin)
-	tokenCfg := bridge.GetTokenConfig(pairID)
+	pairCfg := tokens.GetTokenPairConfigOfSwap(pairID, isSwapin, res.TxHeight, res.TxTime)
+	if pairCfg == nil {
+		return "", tokens.ErrUnknownPairID
+	}
+	tokenCfg := getSwapToTokenConfig(pairCfg, isSwapin)
 	swapType := getSwapType(isSwapin)
 
 	replaceNum := uint64(len(res.OldSwapTxs))
@@ -175,8 +179,12 @@ func replaceSwap(txid, pairID, bind, gasPriceStr string, isSwapin, isManual bool
 			SwapType:   swapType,
 			TxType:     tokens.SwapTxType(swap.TxType),
 			Bind:       bind,
+			TokenPrice: res.TokenPrice,
+
+			PairVersion: pairCfg.Version,
+			PairConfig:  pairCfg,
 		},
-		From:        tokenCfg.DcrmAddress,
+		From:        getSwapSender(tokenCfg, res),
 		OriginFrom:  swap.From,
 		OriginTxTo:  swap.TxTo,
 		OriginValue: swapInfo.Value,
@@ -195,11 +203,7 @@ func replaceSwap(txid, pairID, bind, gasPriceStr string, isSwapin, isManual bool
 	}
 	var signedTx interface{}
 	var signTxHash string
-	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
-		signedTx, signTxHash, err = bridge.SignTransaction(rawTx, pairID)
-	} else {
-		signedTx, signTxHash, err = bridge.DcrmSignTransaction(rawTx, args)
-	}
+	signedTx, signTxHash, err = signSwapTransaction(bridge, rawTx, args)
 	if err != nil {
 		logWorkerError("replaceSwap", "sign tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
 		if errors.Is(err, dcrm.ErrGetSignStatusHasDisagree) {
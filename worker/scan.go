@@ -3,6 +3,7 @@ package worker
 import (
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
 )
 
 // StartScanJob scan job
@@ -15,4 +16,9 @@ func StartScanJob(isServer bool) {
 		}
 		go btc.BridgeInstance.StartSwapHistoryScanJob()
 	}
+	if srcChainCfg.EnableScan {
+		if rb, ok := tokens.SrcBridge.(*ripple.Bridge); ok {
+			go rb.StartChainTransactionScanJob()
+		}
+	}
 }
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	senderBalanceCacheTime = int64(60) // seconds
+
+	// key is isswapin + sender
+	senderBalances    = make(map[string]*senderBalance)
+	senderBalanceLock sync.Mutex
+)
+
+type senderBalance struct {
+	balance   *big.Int
+	timestamp int64
+}
+
+type balanceGetter interface {
+	GetBalance(account string) (*big.Int, error)
+	GetErc20Balance(contract, address string) (*big.Int, error)
+}
+
+type minReserveFeeGetter interface {
+	GetMinReserveFee() *big.Int
+}
+
+// selectSwapSender select sender in dcrm address pool of the token,
+// which has free task channel, enough gas balance and most balance per queued task.
+func selectSwapSender(tokenCfg *tokens.TokenConfig, isSwapin bool) (string, error) {
+	bridge := tokens.GetCrossChainBridge(!isSwapin)
+	if tokenCfg == nil {
+		return "", tokens.ErrUnknownPairID
+	}
+	senders := tokenCfg.GetDcrmAddresses()
+	if len(senders) == 1 {
+		return senders[0], checkSwapTaskChannel(senders[0], isSwapin)
+	}
+	var (
+		selected  string
+		bestScore *big.Int
+	)
+	for _, sender := range senders {
+		if checkSwapTaskChannel(sender, isSwapin) != nil {
+			continue
+		}
+		if !hasEnoughGasBalance(bridge, sender, isSwapin) {
+			continue
+		}
+		queued := big.NewInt(int64(getSwapTaskCount(sender, isSwapin) + 1))
+		score := new(big.Int).Div(getSenderBalance(bridge, tokenCfg, sender, isSwapin), queued)
+		if bestScore == nil || score.Cmp(bestScore) > 0 {
+			selected = sender
+			bestScore = score
+		}
+	}
+	if selected == "" {
+		return "", errNoAvailableSender
+	}
+	return selected, nil
+}
+
+func getSwapTaskCount(sender string, isSwapin bool) int {
+	swapChan, _ := getSwapTaskChannel(sender, isSwapin)
+	return len(swapChan)
+}
+
+// sub accounts of the pool may be not funded, skip them if native balance is less than min reserve fee
+func hasEnoughGasBalance(bridge tokens.CrossChainBridge, sender string, isSwapin bool) bool {
+	getter, ok := bridge.(balanceGetter)
+	if !ok {
+		return true
+	}
+	minBalance := big.NewInt(1)
+	if feeGetter, ok := bridge.(minReserveFeeGetter); ok {
+		if minReserveFee := feeGetter.GetMinReserveFee(); minReserveFee != nil && minReserveFee.Sign() > 0 {
+			minBalance = minReserveFee
+		}
+	}
+	key := "gas:" + getSenderBalanceKey(sender, isSwapin)
+	balance := getCachedBalance(key, func() (*big.Int, error) {
+		return getter.GetBalance(sender)
+	})
+	if balance.Cmp(minBalance) < 0 {
+		logWorkerWarn("swap", "skip sender with insufficient gas balance", "sender", sender, "isSwapin", isSwapin, "balance", balance, "minBalance", minBalance)
+		return false
+	}
+	return true
+}
+
+func getSenderBalanceKey(sender string, isSwapin bool) string {
+	if isSwapin {
+		return "swapin:" + strings.ToLower(sender)
+	}
+	return "swapout:" + strings.ToLower(sender)
+}
+
+// swapout of erc20 token use token balance, otherwise use native balance
+func getSenderBalance(bridge tokens.CrossChainBridge, tokenCfg *tokens.TokenConfig, sender string, isSwapin bool) *big.Int {
+	getter, ok := bridge.(balanceGetter)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return getCachedBalance(getSenderBalanceKey(sender, isSwapin), func() (*big.Int, error) {
+		if !isSwapin && tokenCfg.ContractAddress != "" {
+			return getter.GetErc20Balance(tokenCfg.ContractAddress, sender)
+		}
+		return getter.GetBalance(sender)
+	})
+}
+
+func getCachedBalance(key string, getBalance func() (*big.Int, error)) *big.Int {
+	now := time.Now().Unix()
+
+	senderBalanceLock.Lock()
+	cached, exist := senderBalances[key]
+	senderBalanceLock.Unlock()
+	if exist && cached.timestamp+senderBalanceCacheTime > now {
+		return cached.balance
+	}
+
+	balance := big.NewInt(0)
+	value, err := getBalance()
+	if err != nil {
+		logWorkerWarn("swap", "get sender balance failed", "key", key, "err", err)
+	} else if value != nil {
+		balance = value
+	}
+
+	senderBalanceLock.Lock()
+	senderBalances[key] = &senderBalance{balance: balance, timestamp: now}
+	senderBalanceLock.Unlock()
+	return balance
+}
+
+// getSwapSender get sender of swap result (dcrm address if not in pool)
+func getSwapSender(tokenCfg *tokens.TokenConfig, res *mongodb.MgoSwapResult) string {
+	if res.SwapFrom != "" && tokenCfg.IsDcrmAddress(res.SwapFrom) {
+		return res.SwapFrom
+	}
+	return tokenCfg.DcrmAddress
+}
+
+// signSwapTransaction sign tx by private key or dcrm of the sender
+func signSwapTransaction(bridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error) {
+	tokenCfg := args.GetTokenConfig(bridge.IsSrcEndpoint())
+	if tokenCfg == nil {
+		return nil, "", tokens.ErrUnknownPairID
+	}
+	if tokenCfg.GetPrivateKeyOf(args.From) == nil {
+		return bridge.DcrmSignTransaction(rawTx, args)
+	}
+	if signer, ok := bridge.(tokens.SenderSigner); ok {
+		return signer.SignTransactionWithSender(rawTx, args.PairID, args.From)
+	}
+	return bridge.SignTransaction(rawTx, args.PairID)
+}
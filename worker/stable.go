@@ -149,7 +149,8 @@ func processSwapStable(swap *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 		}
 		if txStatus.IsSwapTxOnChainAndFailed(resBridge.GetTokenConfig(swap.PairID)) {
 			logWorkerWarn("stable", "mark swap result failed with wrong status", "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "isSwapin", isSwapin, "swaptime", swap.Timestamp, "nowtime", now(), "confirmations", txStatus.Confirmations)
-			return markSwapResultFailed(swap.TxID, swap.PairID, swap.Bind, isSwapin)
+			memo := getSwapTxRevertReason(resBridge, swap.SwapTx)
+			return markSwapResultFailed(swap.TxID, swap.PairID, swap.Bind, isSwapin, memo)
 		}
 		return markSwapResultStable(swap.TxID, swap.PairID, swap.Bind, isSwapin)
 	}
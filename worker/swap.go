@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
@@ -24,11 +25,13 @@ var (
 	swapChanSize       = 100
 	swapinTaskChanMap  = make(map[string]chan *tokens.BuildTxArgs)
 	swapoutTaskChanMap = make(map[string]chan *tokens.BuildTxArgs)
+	swapTaskChanLock   sync.RWMutex
 
 	errAlreadySwapped     = errors.New("already swapped")
 	errDBError            = errors.New("database error")
 	errSendTxWithDiffHash = errors.New("send tx with different hash")
 	errSwapChannelIsFull  = errors.New("swap task channel is full")
+	errNoAvailableSender  = errors.New("no available sender (task channel is full or gas balance is insufficient)")
 )
 
 // StartSwapJob swap job
@@ -40,6 +43,7 @@ func StartSwapJob() {
 	if tokens.SrcNonceSetter != nil {
 		tokens.SrcNonceSetter.InitNonces(swapoutNonces)
 	}
+	StartSwapRecoveryJob()
 	for _, pairCfg := range tokens.GetTokenPairsConfig() {
 		AddSwapJob(pairCfg)
 	}
@@ -49,19 +53,25 @@ func StartSwapJob() {
 	go startSwapoutSwapJob()
 }
 
-// AddSwapJob add swap job
+// AddSwapJob add swap job (one task channel per address in dcrm address pool)
 func AddSwapJob(pairCfg *tokens.TokenPairConfig) {
-	swapinDcrmAddr := strings.ToLower(pairCfg.DestToken.DcrmAddress)
-	if _, exist := swapinTaskChanMap[swapinDcrmAddr]; !exist {
-		swapinTaskChanMap[swapinDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
-		utils.TopWaitGroup.Add(1)
-		go processSwapTask(swapinTaskChanMap[swapinDcrmAddr], swapinDcrmAddr, true)
-	}
-	swapoutDcrmAddr := strings.ToLower(pairCfg.SrcToken.DcrmAddress)
-	if _, exist := swapoutTaskChanMap[swapoutDcrmAddr]; !exist {
-		swapoutTaskChanMap[swapoutDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
-		utils.TopWaitGroup.Add(1)
-		go processSwapTask(swapoutTaskChanMap[swapoutDcrmAddr], swapoutDcrmAddr, false)
+	swapTaskChanLock.Lock()
+	defer swapTaskChanLock.Unlock()
+	for _, dcrmAddress := range pairCfg.DestToken.GetDcrmAddresses() {
+		swapinDcrmAddr := strings.ToLower(dcrmAddress)
+		if _, exist := swapinTaskChanMap[swapinDcrmAddr]; !exist {
+			swapinTaskChanMap[swapinDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
+			utils.TopWaitGroup.Add(1)
+			go processSwapTask(swapinTaskChanMap[swapinDcrmAddr], swapinDcrmAddr, true)
+		}
+	}
+	for _, dcrmAddress := range pairCfg.SrcToken.GetDcrmAddresses() {
+		swapoutDcrmAddr := strings.ToLower(dcrmAddress)
+		if _, exist := swapoutTaskChanMap[swapoutDcrmAddr]; !exist {
+			swapoutTaskChanMap[swapoutDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
+			utils.TopWaitGroup.Add(1)
+			go processSwapTask(swapoutTaskChanMap[swapoutDcrmAddr], swapoutDcrmAddr, false)
+		}
 	}
 }
 
@@ -110,6 +120,7 @@ func processSwapins(status mongodb.SwapStatus) {
 		case err == nil,
 			errors.Is(err, errAlreadySwapped),
 			errors.Is(err, errSwapChannelIsFull),
+			errors.Is(err, errSwapIsRecovering),
 			errors.Is(err, errDBError),
 			errors.Is(err, tokens.ErrUnknownPairID),
 			errors.Is(err, tokens.ErrAddressIsInBlacklist),
@@ -138,6 +149,7 @@ func processSwapouts(status mongodb.SwapStatus) {
 		case err == nil,
 			errors.Is(err, errAlreadySwapped),
 			errors.Is(err, errSwapChannelIsFull),
+			errors.Is(err, errSwapIsRecovering),
 			errors.Is(err, errDBError),
 			errors.Is(err, tokens.ErrUnknownPairID),
 			errors.Is(err, tokens.ErrAddressIsInBlacklist),
@@ -159,19 +171,12 @@ func findSwapoutsToSwap(status mongodb.SwapStatus) ([]*mongodb.MgoSwap, error) {
 }
 
 func isSwapInBlacklist(swap *mongodb.MgoSwapResult) (isBlacked bool, err error) {
-	isBlacked, err = mongodb.QueryBlacklist(swap.From, swap.PairID)
+	txTo := getScreenedTxTo(swap.PairID, swap.TxTo)
+	isBlacked, err = isAnyInBlacklist(swap.PairID, swap.From, swap.Bind, txTo)
 	if err != nil {
 		logWorkerTrace("swap", "query blacklist failed", "err", err)
-		return isBlacked, err
-	}
-	if !isBlacked && swap.Bind != swap.From {
-		isBlacked, err = mongodb.QueryBlacklist(swap.Bind, swap.PairID)
-		if err != nil {
-			logWorkerTrace("swap", "query blacklist failed", "err", err)
-			return isBlacked, err
-		}
 	}
-	return isBlacked, nil
+	return isBlacked, err
 }
 
 func processSwapinSwap(swap *mongodb.MgoSwap) (err error) {
@@ -192,6 +197,10 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 		return errAlreadySwapped
 	}
 
+	if isSwapSignLogExist(isSwapin, txid, pairID, bind) {
+		return errSwapIsRecovering
+	}
+
 	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
 	if err != nil {
 		return err
@@ -202,12 +211,18 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 		return err
 	}
 
-	dcrmAddress, err := checkSwapResult(res, isSwapin)
+	err = checkSwapResult(res, isSwapin)
 	if err != nil {
 		return err
 	}
 
-	if err = checkSwapTaskChannel(dcrmAddress, isSwapin); err != nil {
+	pairCfg := tokens.GetTokenPairConfigOfSwap(pairID, isSwapin, res.TxHeight, res.TxTime)
+	if pairCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+
+	dcrmAddress, err := selectSwapSender(getSwapToTokenConfig(pairCfg, isSwapin), isSwapin)
+	if err != nil {
 		return err
 	}
 
@@ -235,6 +250,10 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 			TxType:     tokens.SwapTxType(swap.TxType),
 			Bind:       bind,
 			Reswapping: res.Status == mongodb.Reswapping,
+			TokenPrice: tokens.GetTokenPrice(pairID, isSwapin),
+
+			PairVersion: pairCfg.Version,
+			PairConfig:  pairCfg,
 		},
 		From:        dcrmAddress,
 		OriginFrom:  swap.From,
@@ -245,7 +264,7 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 	return dispatchSwapTask(args)
 }
 
-func checkSwapResult(res *mongodb.MgoSwapResult, isSwapin bool) (dcrmAddress string, err error) {
+func checkSwapResult(res *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 	pairID := res.PairID
 	txid := res.TxID
 	bind := res.Bind
@@ -253,24 +272,24 @@ func checkSwapResult(res *mongodb.MgoSwapResult, isSwapin bool) (dcrmAddress str
 	fromTokenCfg, toTokenCfg := tokens.GetTokenConfigsByDirection(pairID, isSwapin)
 	if fromTokenCfg == nil || toTokenCfg == nil {
 		logWorkerTrace("swap", "swap is not configed", "pairID", pairID, "isSwapin", isSwapin)
-		return "", tokens.ErrUnknownPairID
+		return tokens.ErrUnknownPairID
 	}
 	if fromTokenCfg.DisableSwap {
 		logWorkerTrace("swap", "swap is disabled", "pairID", pairID, "isSwapin", isSwapin)
-		return "", tokens.ErrSwapIsClosed
+		return tokens.ErrSwapIsClosed
 	}
 	isBlacked, err := isSwapInBlacklist(res)
 	if err != nil {
-		return "", errDBError
+		return errDBError
 	}
 	if isBlacked {
 		logWorkerTrace("swap", "address is in blacklist", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
 		err = tokens.ErrAddressIsInBlacklist
 		_ = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.SwapInBlacklist, now(), err.Error())
-		return "", err
+		return err
 	}
 
-	return toTokenCfg.DcrmAddress, nil
+	return nil
 }
 
 func preventReswap(res *mongodb.MgoSwapResult, isSwapin bool) error {
@@ -333,22 +352,25 @@ func preventReswapByHistory(res *mongodb.MgoSwapResult, isSwapin bool) error {
 	return nil
 }
 
-func checkSwapTaskChannel(sender string, isSwapin bool) error {
-	var (
-		swapChan chan *tokens.BuildTxArgs
-		exist    bool
-	)
+func getSwapTaskChannel(sender string, isSwapin bool) (swapChan chan *tokens.BuildTxArgs, exist bool) {
 	from := strings.ToLower(sender)
+	swapTaskChanLock.RLock()
+	defer swapTaskChanLock.RUnlock()
 	if isSwapin {
 		swapChan, exist = swapinTaskChanMap[from]
-		if !exist {
-			return fmt.Errorf("no swapin task channel for dcrm address '%v'", sender)
-		}
 	} else {
 		swapChan, exist = swapoutTaskChanMap[from]
-		if !exist {
-			return fmt.Errorf("no swapout task channel for dcrm address '%v'", sender)
+	}
+	return swapChan, exist
+}
+
+func checkSwapTaskChannel(sender string, isSwapin bool) error {
+	swapChan, exist := getSwapTaskChannel(sender, isSwapin)
+	if !exist {
+		if isSwapin {
+			return fmt.Errorf("no swapin task channel for dcrm address '%v'", sender)
 		}
+		return fmt.Errorf("no swapout task channel for dcrm address '%v'", sender)
 	}
 	if len(swapChan) == cap(swapChan) {
 		logWorkerWarn("doSwap", "swap task channel is full", "sender", sender, "isSwapin", isSwapin)
@@ -365,12 +387,12 @@ func dispatchSwapTask(args *tokens.BuildTxArgs) error {
 	from := strings.ToLower(args.From)
 	switch args.SwapType {
 	case tokens.SwapinType:
-		swapChan, exist = swapinTaskChanMap[from]
+		swapChan, exist = getSwapTaskChannel(from, true)
 		if !exist {
 			return fmt.Errorf("no swapin task channel for dcrm address '%v'", args.From)
 		}
 	case tokens.SwapoutType:
-		swapChan, exist = swapoutTaskChanMap[from]
+		swapChan, exist = getSwapTaskChannel(from, false)
 		if !exist {
 			return fmt.Errorf("no swapout task channel for dcrm address '%v'", args.From)
 		}
@@ -453,20 +475,27 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	rawTx, err := resBridge.BuildRawTransaction(args)
 	if err != nil {
 		logWorkerError("doSwap", "build tx failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		if errors.Is(err, tokens.ErrTxWouldRevert) {
+			_ = mongodb.UpdateSwapResultStatus(isSwapin, txid, pairID, bind, mongodb.MatchTxWouldRevert, now(), err.Error())
+			_ = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxProcessed, now(), err.Error())
+		}
 		return err
 	}
 
 	swapNonce := args.GetTxNonce()
 
+	// write ahead to resume swap if crashed during signing
+	err = addSwapSignLog(resBridge, rawTx, args)
+	if err != nil {
+		logWorkerError("doSwap", "add swap sign log failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		return errDBError
+	}
+	defer removeSwapSignLog(resBridge, args)
+
 	var signedTx interface{}
 	var signTxHash string
-	tokenCfg := resBridge.GetTokenConfig(pairID)
 	for i := 1; i <= 3; i++ { // with retry
-		if tokenCfg.GetDcrmAddressPrivateKey() != nil {
-			signedTx, signTxHash, err = resBridge.SignTransaction(rawTx, pairID)
-		} else {
-			signedTx, signTxHash, err = resBridge.DcrmSignTransaction(rawTx, args)
-		}
+		signedTx, signTxHash, err = signSwapTransaction(resBridge, rawTx, args)
 		if err == nil {
 			break
 		}
@@ -479,6 +508,7 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		}
 		return err
 	}
+	updateSwapSignLogSigned(resBridge, signedTx, signTxHash, args)
 
 	// recheck reswap before update db
 	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
@@ -492,14 +522,16 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 
 	// update database before sending transaction
 	matchTx := &MatchTx{
-		SwapTx:    signTxHash,
-		SwapType:  swapType,
-		SwapNonce: swapNonce,
+		SwapTx:     signTxHash,
+		SwapType:   swapType,
+		SwapNonce:  swapNonce,
+		TokenPrice: args.TokenPrice,
+		SwapFrom:   args.From,
 	}
 	if args.SwapValue != nil {
 		matchTx.SwapValue = args.SwapValue.String()
 	} else {
-		matchTx.SwapValue = tokens.CalcSwappedValue(pairID, args.OriginValue, isSwapin, res.From, res.TxTo).String()
+		matchTx.SwapValue = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, res.From, res.TxTo, args.TokenPrice).String()
 	}
 	err = updateSwapResult(txid, pairID, bind, matchTx)
 	if err != nil {
@@ -0,0 +1,328 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	errSwapIsRecovering = errors.New("swap is recovering from sign log")
+
+	swapRecoveryInterval    = 60 * time.Second
+	maxSwapRecoveryAttempts = 10
+
+	signLogStore swapSignLogStore = mgoSwapSignLogStore{}
+)
+
+// swapSignLogStore store write ahead logs of signing swap txs
+type swapSignLogStore interface {
+	Add(ml *mongodb.MgoSwapSignLog) error
+	UpdateKeyID(isSwapin bool, txid, pairID, bind, keyID, signCtx string) error
+	UpdateSigned(isSwapin bool, txid, pairID, bind, signedTx, swapTx string) error
+	Remove(isSwapin bool, txid, pairID, bind string) error
+	Find(isSwapin bool, txid, pairID, bind string) (*mongodb.MgoSwapSignLog, error)
+	FindAll() ([]*mongodb.MgoSwapSignLog, error)
+}
+
+type mgoSwapSignLogStore struct{}
+
+func (mgoSwapSignLogStore) Add(ml *mongodb.MgoSwapSignLog) error {
+	return mongodb.AddSwapSignLog(ml)
+}
+
+func (mgoSwapSignLogStore) UpdateKeyID(isSwapin bool, txid, pairID, bind, keyID, signCtx string) error {
+	return mongodb.UpdateSwapSignLogKeyID(isSwapin, txid, pairID, bind, keyID, signCtx)
+}
+
+func (mgoSwapSignLogStore) UpdateSigned(isSwapin bool, txid, pairID, bind, signedTx, swapTx string) error {
+	return mongodb.UpdateSwapSignLogSigned(isSwapin, txid, pairID, bind, signedTx, swapTx)
+}
+
+func (mgoSwapSignLogStore) Remove(isSwapin bool, txid, pairID, bind string) error {
+	return mongodb.RemoveSwapSignLog(isSwapin, txid, pairID, bind)
+}
+
+func (mgoSwapSignLogStore) Find(isSwapin bool, txid, pairID, bind string) (*mongodb.MgoSwapSignLog, error) {
+	return mongodb.FindSwapSignLog(isSwapin, txid, pairID, bind)
+}
+
+func (mgoSwapSignLogStore) FindAll() ([]*mongodb.MgoSwapSignLog, error) {
+	return mongodb.FindAllSwapSignLogs()
+}
+
+// StartSwapRecoveryJob resume swaps interrupted during signing or sending,
+// it should be called after nonces inited and before swap tasks started.
+func StartSwapRecoveryJob() {
+	dcrm.SignRequestedHook = onSignRequested
+
+	signLogs, err := signLogStore.FindAll()
+	if err != nil {
+		logWorkerError("recovery", "find swap sign logs failed", err)
+		return
+	}
+	if len(signLogs) == 0 {
+		return
+	}
+	for _, ml := range signLogs {
+		reserveSwapSignLogNonce(ml)
+	}
+	mongodb.MgoWaitGroup.Add(1)
+	go loopRecoverSwapSignLogs(signLogs)
+}
+
+// onSignRequested persist keyID of signing swap
+func onSignRequested(keyID string, msgHash, msgContext []string) {
+	if len(msgContext) == 0 {
+		return
+	}
+	var args tokens.BuildTxArgs
+	err := json.Unmarshal([]byte(msgContext[0]), &args)
+	if err != nil || args.SwapID == "" || args.Identifier != params.GetIdentifier() {
+		return
+	}
+	err = signLogStore.UpdateKeyID(args.IsSwapin(), args.SwapID, args.PairID, args.Bind, keyID, msgContext[0])
+	if err != nil {
+		logWorkerError("recovery", "update sign log keyID failed", err, "keyID", keyID, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind)
+	}
+}
+
+// prevent new swaps from using the nonce of the swap to be recovered
+func reserveSwapSignLogNonce(ml *mongodb.MgoSwapSignLog) {
+	nonceSetter, ok := tokens.GetCrossChainBridge(!ml.IsSwapin).(tokens.NonceSetter)
+	if !ok {
+		return
+	}
+	var args tokens.BuildTxArgs
+	if err := json.Unmarshal([]byte(ml.Args), &args); err != nil || args.From == "" {
+		return
+	}
+	nonceSetter.SetSenderNonce(args.From, args.GetTxNonce()+1)
+}
+
+func isSwapSignLogExist(isSwapin bool, txid, pairID, bind string) bool {
+	ml, _ := signLogStore.Find(isSwapin, txid, pairID, bind)
+	return ml != nil
+}
+
+// addSwapSignLog write ahead before signing swap tx
+func addSwapSignLog(bridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs) error {
+	resumer, ok := bridge.(tokens.SignResumer)
+	if !ok {
+		return nil
+	}
+	encRawTx, err := resumer.EncodeTx(rawTx)
+	if err != nil {
+		return err
+	}
+	jsArgs, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return signLogStore.Add(&mongodb.MgoSwapSignLog{
+		IsSwapin: args.IsSwapin(),
+		PairID:   args.PairID,
+		TxID:     args.SwapID,
+		Bind:     args.Bind,
+		Args:     string(jsArgs),
+		RawTx:    encRawTx,
+		Status:   mongodb.SwapSignLogSigning,
+	})
+}
+
+// updateSwapSignLogSigned write ahead before sending signed swap tx
+func updateSwapSignLogSigned(bridge tokens.CrossChainBridge, signedTx interface{}, txHash string, args *tokens.BuildTxArgs) {
+	resumer, ok := bridge.(tokens.SignResumer)
+	if !ok {
+		return
+	}
+	encSignedTx, err := resumer.EncodeTx(signedTx)
+	if err == nil {
+		err = signLogStore.UpdateSigned(args.IsSwapin(), args.SwapID, args.PairID, args.Bind, encSignedTx, txHash)
+	}
+	if err != nil {
+		logWorkerError("recovery", "update sign log signed tx failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swaptx", txHash)
+	}
+}
+
+func removeSwapSignLog(bridge tokens.CrossChainBridge, args *tokens.BuildTxArgs) {
+	if _, ok := bridge.(tokens.SignResumer); !ok {
+		return
+	}
+	_ = signLogStore.Remove(args.IsSwapin(), args.SwapID, args.PairID, args.Bind)
+}
+
+// retry failed recoveries periodically, as the swaps are blocked until their
+// sign logs are removed. signed swap tx may be already broadcasted, so resend
+// it until success, otherwise drop the sign log after too many failed attempts
+// to let the swap be rebuilt.
+func loopRecoverSwapSignLogs(signLogs []*mongodb.MgoSwapSignLog) {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("recovery", "start swap recovery job", "count", len(signLogs))
+	attempts := make(map[string]int)
+	for {
+		signLogs = recoverSwapSignLogs(signLogs, attempts, recoverSwapSignLog)
+		if len(signLogs) == 0 {
+			break
+		}
+		if utils.IsCleanuping() {
+			logWorker("recovery", "stop swap recovery job", "remain", len(signLogs))
+			return
+		}
+		restInJob(swapRecoveryInterval)
+	}
+	logWorker("recovery", "finish swap recovery job")
+}
+
+func recoverSwapSignLogs(signLogs []*mongodb.MgoSwapSignLog, attempts map[string]int, recoverFn func(*mongodb.MgoSwapSignLog) error) (failed []*mongodb.MgoSwapSignLog) {
+	for _, ml := range signLogs {
+		if attempts[ml.Key] > 0 {
+			// reload as it may be updated in the last attempt
+			latest, err := signLogStore.Find(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind)
+			if err != nil {
+				if errors.Is(err, mongodb.ErrItemNotFound) {
+					continue
+				}
+				failed = append(failed, ml)
+				continue
+			}
+			ml = latest
+		}
+		err := recoverFn(ml)
+		if err == nil {
+			continue
+		}
+		attempts[ml.Key]++
+		logWorkerError("recovery", "recover swap failed", err, "isSwapin", ml.IsSwapin, "pairID", ml.PairID, "txid", ml.TxID, "bind", ml.Bind, "status", ml.Status, "attempts", attempts[ml.Key])
+		if ml.Status != mongodb.SwapSignLogSigned && attempts[ml.Key] >= maxSwapRecoveryAttempts {
+			logWorkerWarn("recovery", "drop swap sign log after too many failed attempts, rebuild it later", "isSwapin", ml.IsSwapin, "pairID", ml.PairID, "txid", ml.TxID, "bind", ml.Bind)
+			_ = signLogStore.Remove(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind)
+			continue
+		}
+		failed = append(failed, ml)
+	}
+	return failed
+}
+
+func recoverSwapSignLog(ml *mongodb.MgoSwapSignLog) (err error) {
+	isSwapin := ml.IsSwapin
+	bridge := tokens.GetCrossChainBridge(!isSwapin)
+	resumer, ok := bridge.(tokens.SignResumer)
+	if !ok {
+		return signLogStore.Remove(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+	}
+
+	res, err := mongodb.FindSwapResult(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+	if err != nil {
+		return err
+	}
+
+	var args tokens.BuildTxArgs
+	err = json.Unmarshal([]byte(ml.Args), &args)
+	if err != nil || (res.SwapTx != "" && !strings.EqualFold(res.SwapTx, ml.SwapTx)) {
+		// swap is rebuilt or already handled by other tx
+		logWorkerWarn("recovery", "drop swap sign log", "isSwapin", isSwapin, "pairID", ml.PairID, "txid", ml.TxID, "bind", ml.Bind, "swaptx", res.SwapTx, "logswaptx", ml.SwapTx, "err", err)
+		return signLogStore.Remove(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+	}
+	args.PairConfig = tokens.GetTokenPairConfigOfSwap(ml.PairID, isSwapin, res.TxHeight, res.TxTime)
+
+	var signedTx interface{}
+	txHash := ml.SwapTx
+	switch {
+	case ml.Status == mongodb.SwapSignLogSigned:
+		signedTx, err = resumer.DecodeTx(ml.SignedTx)
+	case ml.KeyID != "":
+		signedTx, txHash, err = resumeSignSwap(bridge, ml, &args)
+	default:
+		// sign request may be not accepted, rebuild swap after max attempts
+		err = errors.New("sign request has no keyID")
+	}
+	if err != nil {
+		if !isSignDefinitelyFailed(err) {
+			// retry later, eg. get sign status rpc failed or sign is pending
+			return err
+		}
+		logWorkerWarn("recovery", "can not resume swap, rebuild it later", "isSwapin", isSwapin, "pairID", ml.PairID, "txid", ml.TxID, "bind", ml.Bind, "keyID", ml.KeyID, "err", err)
+		return signLogStore.Remove(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+	}
+
+	if res.SwapTx == "" {
+		err = commitRecoveredSwap(res, &args, txHash)
+		if err != nil {
+			if errors.Is(err, errAlreadySwapped) {
+				return signLogStore.Remove(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+			}
+			return err
+		}
+	}
+
+	sentTxHash, err := sendSignedTransaction(bridge, signedTx, &args)
+	if err != nil {
+		return err
+	}
+	logWorker("recovery", "resend recovered swap tx success", "isSwapin", isSwapin, "pairID", ml.PairID, "txid", ml.TxID, "bind", ml.Bind, "swaptx", sentTxHash)
+	return signLogStore.Remove(isSwapin, ml.TxID, ml.PairID, ml.Bind)
+}
+
+func resumeSignSwap(bridge tokens.CrossChainBridge, ml *mongodb.MgoSwapSignLog, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error) {
+	resumer := bridge.(tokens.SignResumer)
+	rawTx, err := resumer.DecodeTx(ml.RawTx)
+	if err != nil {
+		return nil, "", err
+	}
+	// sign context has the args (eg. gas price) actually signed with
+	if ml.SignCtx != "" {
+		var signArgs tokens.BuildTxArgs
+		if err = json.Unmarshal([]byte(ml.SignCtx), &signArgs); err == nil {
+			args.Extra = signArgs.Extra
+		}
+	}
+	signedTx, txHash, err = resumer.ResumeDcrmSignTransaction(rawTx, args, ml.KeyID)
+	if err != nil {
+		if errors.Is(err, dcrm.ErrGetSignStatusHasDisagree) {
+			reverifySwap(args)
+		}
+		return nil, "", err
+	}
+	updateSwapSignLogSigned(bridge, signedTx, txHash, args)
+	return signedTx, txHash, nil
+}
+
+// is sign finished without result, the sign log can be removed safely
+func isSignDefinitelyFailed(err error) bool {
+	return errors.Is(err, dcrm.ErrGetSignStatusHasDisagree) ||
+		errors.Is(err, dcrm.ErrGetSignStatusFailed) ||
+		errors.Is(err, dcrm.ErrGetSignStatusTimeout)
+}
+
+func commitRecoveredSwap(res *mongodb.MgoSwapResult, args *tokens.BuildTxArgs, txHash string) error {
+	isSwapin := args.IsSwapin()
+	err := preventReswap(res, isSwapin)
+	if err != nil {
+		return err
+	}
+	matchTx := &MatchTx{
+		SwapTx:     txHash,
+		SwapType:   args.SwapType,
+		SwapNonce:  args.GetTxNonce(),
+		TokenPrice: args.TokenPrice,
+		SwapFrom:   args.From,
+	}
+	if args.SwapValue != nil {
+		matchTx.SwapValue = args.SwapValue.String()
+	} else {
+		matchTx.SwapValue = tokens.CalcSwappedValueOfSwap(&args.SwapInfo, args.OriginValue, res.From, res.TxTo, args.TokenPrice).String()
+	}
+	err = updateSwapResult(args.SwapID, args.PairID, args.Bind, matchTx)
+	if err != nil {
+		return err
+	}
+	return mongodb.UpdateSwapStatus(isSwapin, args.SwapID, args.PairID, args.Bind, mongodb.TxProcessed, now(), "")
+}
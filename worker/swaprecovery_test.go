@@ -0,0 +1,309 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const testIdentifier = "testbridge"
+
+type memSwapSignLogStore struct {
+	logs map[string]*mongodb.MgoSwapSignLog
+}
+
+func newMemSwapSignLogStore() *memSwapSignLogStore {
+	return &memSwapSignLogStore{logs: make(map[string]*mongodb.MgoSwapSignLog)}
+}
+
+func memSwapSignLogKey(isSwapin bool, txid, pairID, bind string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v:%v:%v", txid, pairID, bind, isSwapin))
+}
+
+func (s *memSwapSignLogStore) Add(ml *mongodb.MgoSwapSignLog) error {
+	ml.Key = memSwapSignLogKey(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind)
+	item := *ml
+	s.logs[ml.Key] = &item
+	return nil
+}
+
+func (s *memSwapSignLogStore) UpdateKeyID(isSwapin bool, txid, pairID, bind, keyID, signCtx string) error {
+	ml := s.logs[memSwapSignLogKey(isSwapin, txid, pairID, bind)]
+	if ml != nil && ml.Status == mongodb.SwapSignLogSigning {
+		ml.KeyID = keyID
+		ml.SignCtx = signCtx
+	}
+	return nil
+}
+
+func (s *memSwapSignLogStore) UpdateSigned(isSwapin bool, txid, pairID, bind, signedTx, swapTx string) error {
+	ml := s.logs[memSwapSignLogKey(isSwapin, txid, pairID, bind)]
+	if ml == nil {
+		return mongodb.ErrItemNotFound
+	}
+	ml.SignedTx = signedTx
+	ml.SwapTx = swapTx
+	ml.Status = mongodb.SwapSignLogSigned
+	return nil
+}
+
+func (s *memSwapSignLogStore) Remove(isSwapin bool, txid, pairID, bind string) error {
+	delete(s.logs, memSwapSignLogKey(isSwapin, txid, pairID, bind))
+	return nil
+}
+
+func (s *memSwapSignLogStore) Find(isSwapin bool, txid, pairID, bind string) (*mongodb.MgoSwapSignLog, error) {
+	ml := s.logs[memSwapSignLogKey(isSwapin, txid, pairID, bind)]
+	if ml == nil {
+		return nil, mongodb.ErrItemNotFound
+	}
+	item := *ml
+	return &item, nil
+}
+
+func (s *memSwapSignLogStore) FindAll() ([]*mongodb.MgoSwapSignLog, error) {
+	result := make([]*mongodb.MgoSwapSignLog, 0, len(s.logs))
+	for _, ml := range s.logs {
+		item := *ml
+		result = append(result, &item)
+	}
+	return result, nil
+}
+
+type testBridge struct {
+	tokens.CrossChainBridge
+}
+
+type testResumerBridge struct {
+	testBridge
+}
+
+func (b *testResumerBridge) EncodeTx(tx interface{}) (string, error) {
+	return fmt.Sprint(tx), nil
+}
+
+func (b *testResumerBridge) DecodeTx(data string) (interface{}, error) {
+	return data, nil
+}
+
+func (b *testResumerBridge) ResumeDcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs, keyID string) (signedTx interface{}, txHash string, err error) {
+	return nil, "", errors.New("not supported")
+}
+
+func setupSwapRecoveryTest(t *testing.T) *memSwapSignLogStore {
+	oldStore := signLogStore
+	oldConfig := params.GetConfig()
+	store := newMemSwapSignLogStore()
+	signLogStore = store
+	params.SetConfig(&params.BridgeConfig{Identifier: testIdentifier})
+	t.Cleanup(func() {
+		signLogStore = oldStore
+		if oldConfig != nil {
+			params.SetConfig(oldConfig)
+		}
+	})
+	return store
+}
+
+func newTestSwapArgs() *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			Identifier: testIdentifier,
+			PairID:     "usdt",
+			SwapID:     "0x1111",
+			SwapType:   tokens.SwapinType,
+			Bind:       "0x2222",
+		},
+		From: "0x3333",
+	}
+}
+
+func TestSwapSignLogLifecycle(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	bridge := &testResumerBridge{}
+	args := newTestSwapArgs()
+
+	if err := addSwapSignLog(bridge, "rawtx", args); err != nil {
+		t.Fatalf("add sign log failed: %v", err)
+	}
+	if !isSwapSignLogExist(true, args.SwapID, args.PairID, args.Bind) {
+		t.Fatal("sign log not exist after added")
+	}
+	ml, _ := store.Find(true, args.SwapID, args.PairID, args.Bind)
+	if ml.Status != mongodb.SwapSignLogSigning || ml.RawTx != "rawtx" {
+		t.Fatalf("wrong sign log after added: status %v rawtx %v", ml.Status, ml.RawTx)
+	}
+
+	msgContext, _ := json.Marshal(args)
+	onSignRequested("keyid", []string{"msghash"}, []string{string(msgContext)})
+	ml, _ = store.Find(true, args.SwapID, args.PairID, args.Bind)
+	if ml.KeyID != "keyid" || ml.SignCtx != string(msgContext) {
+		t.Fatalf("keyID not recorded on sign requested: %v", ml.KeyID)
+	}
+
+	updateSwapSignLogSigned(bridge, "signedtx", "0x4444", args)
+	ml, _ = store.Find(true, args.SwapID, args.PairID, args.Bind)
+	if ml.Status != mongodb.SwapSignLogSigned || ml.SignedTx != "signedtx" || ml.SwapTx != "0x4444" {
+		t.Fatalf("wrong sign log after signed: status %v signedtx %v swaptx %v", ml.Status, ml.SignedTx, ml.SwapTx)
+	}
+
+	removeSwapSignLog(bridge, args)
+	if isSwapSignLogExist(true, args.SwapID, args.PairID, args.Bind) {
+		t.Fatal("sign log exist after removed")
+	}
+}
+
+func TestSwapSignLogOfNonResumerBridge(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	if err := addSwapSignLog(&testBridge{}, "rawtx", newTestSwapArgs()); err != nil {
+		t.Fatalf("add sign log failed: %v", err)
+	}
+	if len(store.logs) != 0 {
+		t.Fatal("sign log added for bridge not supporting resume")
+	}
+}
+
+func TestOnSignRequestedIgnoreOthers(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	args := newTestSwapArgs()
+	if err := addSwapSignLog(&testResumerBridge{}, "rawtx", args); err != nil {
+		t.Fatalf("add sign log failed: %v", err)
+	}
+
+	other := newTestSwapArgs()
+	other.Identifier = tokens.CancelNonceIdentifier
+	msgContext, _ := json.Marshal(other)
+	onSignRequested("keyid", []string{"msghash"}, []string{string(msgContext)})
+	onSignRequested("keyid", []string{"msghash"}, []string{"invalid json"})
+	onSignRequested("keyid", []string{"msghash"}, nil)
+
+	ml, _ := store.Find(true, args.SwapID, args.PairID, args.Bind)
+	if ml.KeyID != "" {
+		t.Fatalf("keyID recorded of other sign request: %v", ml.KeyID)
+	}
+}
+
+func TestRecoverSwapSignLogOfNonResumerBridge(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	oldBridge := tokens.DstBridge
+	tokens.DstBridge = &testBridge{}
+	t.Cleanup(func() { tokens.DstBridge = oldBridge })
+
+	args := newTestSwapArgs()
+	_ = store.Add(&mongodb.MgoSwapSignLog{
+		IsSwapin: true,
+		PairID:   args.PairID,
+		TxID:     args.SwapID,
+		Bind:     args.Bind,
+		Status:   mongodb.SwapSignLogSigning,
+	})
+	ml, _ := store.Find(true, args.SwapID, args.PairID, args.Bind)
+	if err := recoverSwapSignLog(ml); err != nil {
+		t.Fatalf("recover sign log failed: %v", err)
+	}
+	if len(store.logs) != 0 {
+		t.Fatal("sign log not removed")
+	}
+}
+
+func TestIsSignDefinitelyFailed(t *testing.T) {
+	for _, err := range []error{dcrm.ErrGetSignStatusHasDisagree, dcrm.ErrGetSignStatusFailed, dcrm.ErrGetSignStatusTimeout} {
+		if !isSignDefinitelyFailed(fmt.Errorf("resume sign: %w", err)) {
+			t.Errorf("%v should be definitely failed", err)
+		}
+	}
+	if isSignDefinitelyFailed(errors.New("getSignStatus rpc error")) {
+		t.Error("rpc error should be retried")
+	}
+}
+
+func TestRecoverSwapSignLogsRetry(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	for i, status := range []string{mongodb.SwapSignLogSigning, mongodb.SwapSignLogSigned} {
+		_ = store.Add(&mongodb.MgoSwapSignLog{
+			IsSwapin: true,
+			PairID:   "usdt",
+			TxID:     fmt.Sprintf("0x%d", i),
+			Bind:     "0x2222",
+			Status:   status,
+		})
+	}
+	signLogs, _ := store.FindAll()
+
+	recoverFails := func(*mongodb.MgoSwapSignLog) error { return errors.New("recover failed") }
+	attempts := make(map[string]int)
+	for i := 1; i < maxSwapRecoveryAttempts; i++ {
+		signLogs = recoverSwapSignLogs(signLogs, attempts, recoverFails)
+		if len(signLogs) != 2 || len(store.logs) != 2 {
+			t.Fatalf("sign logs dropped before max attempts: attempts %v remain %v", i, len(signLogs))
+		}
+	}
+
+	// signing log is dropped to rebuild the swap, signed log is kept to resend
+	signLogs = recoverSwapSignLogs(signLogs, attempts, recoverFails)
+	if len(signLogs) != 1 || signLogs[0].Status != mongodb.SwapSignLogSigned {
+		t.Fatalf("wrong remain sign logs after max attempts: %v", len(signLogs))
+	}
+	if _, err := store.Find(true, "0x0", "usdt", "0x2222"); !errors.Is(err, mongodb.ErrItemNotFound) {
+		t.Fatal("signing log not removed after max attempts")
+	}
+
+	recoverSucceeds := func(ml *mongodb.MgoSwapSignLog) error {
+		return store.Remove(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind)
+	}
+	signLogs = recoverSwapSignLogs(signLogs, attempts, recoverSucceeds)
+	if len(signLogs) != 0 || len(store.logs) != 0 {
+		t.Fatalf("sign logs remain after recovered: %v", len(signLogs))
+	}
+}
+
+func TestRecoverSwapSignLogsReload(t *testing.T) {
+	store := setupSwapRecoveryTest(t)
+	_ = store.Add(&mongodb.MgoSwapSignLog{
+		IsSwapin: true,
+		PairID:   "usdt",
+		TxID:     "0x1111",
+		Bind:     "0x2222",
+		Status:   mongodb.SwapSignLogSigning,
+	})
+	signLogs, _ := store.FindAll()
+	attempts := make(map[string]int)
+
+	// signed in the first attempt but failed to send
+	signLogs = recoverSwapSignLogs(signLogs, attempts, func(ml *mongodb.MgoSwapSignLog) error {
+		_ = store.UpdateSigned(ml.IsSwapin, ml.TxID, ml.PairID, ml.Bind, "signedtx", "0x4444")
+		return errors.New("send tx failed")
+	})
+	if len(signLogs) != 1 {
+		t.Fatalf("failed sign log is not retried")
+	}
+
+	var recovered *mongodb.MgoSwapSignLog
+	signLogs = recoverSwapSignLogs(signLogs, attempts, func(ml *mongodb.MgoSwapSignLog) error {
+		recovered = ml
+		return nil
+	})
+	if len(signLogs) != 0 {
+		t.Fatalf("recovered sign log is retried")
+	}
+	if recovered == nil || recovered.Status != mongodb.SwapSignLogSigned || recovered.SwapTx != "0x4444" {
+		t.Fatal("sign log is not reloaded before retry")
+	}
+
+	// removed by others (eg. swap is rebuilt)
+	_ = store.Remove(true, "0x1111", "usdt", "0x2222")
+	signLogs = recoverSwapSignLogs([]*mongodb.MgoSwapSignLog{recovered}, attempts, func(*mongodb.MgoSwapSignLog) error {
+		t.Fatal("removed sign log is recovered")
+		return nil
+	})
+	if len(signLogs) != 0 {
+		t.Fatalf("removed sign log is retried")
+	}
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
+)
+
+var checkTrustlineInterval = 10 * time.Minute
+
+// StartCheckTrustlineJob check trustlines of ripple non native pairs
+func StartCheckTrustlineJob() {
+	rippleBridge, ok := tokens.SrcBridge.(*ripple.Bridge)
+	if !ok {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopCheckTrustline(rippleBridge)
+}
+
+func loopCheckTrustline(rippleBridge *ripple.Bridge) {
+	defer mongodb.MgoWaitGroup.Done()
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			return
+		}
+		logWorkerTrace("trustline", "start check trustline job", "loop", loop)
+		rippleBridge.CheckTrustlines()
+		logWorkerTrace("trustline", "finish check trustline job", "loop", loop)
+		restInJob(checkTrustlineInterval)
+	}
+}
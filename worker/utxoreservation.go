@@ -0,0 +1,124 @@
+package worker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+)
+
+var (
+	utxoReservationPageLimit       = 100
+	releaseUtxoReservationInterval = 5 * time.Minute
+)
+
+// utxo bridges (btc, ltc, block, colx) which reserve utxos
+type utxoReservationBridge interface {
+	tokens.CrossChainBridge
+	GetOutspend(txHash string, vout uint32) (*electrs.ElectOutspend, error)
+}
+
+func getUtxoReservationBridges() (bridges []utxoReservationBridge) {
+	for _, bridge := range []tokens.CrossChainBridge{tokens.SrcBridge, tokens.DstBridge} {
+		if utxoBridge, ok := bridge.(utxoReservationBridge); ok {
+			bridges = append(bridges, utxoBridge)
+		}
+	}
+	return bridges
+}
+
+// getUtxoReservationBridge get bridge of reservation by its address
+func getUtxoReservationBridge(res *mongodb.MgoUtxoReservation) utxoReservationBridge {
+	bridges := getUtxoReservationBridges()
+	for _, bridge := range bridges {
+		if bridge.IsValidAddress(res.Address) {
+			return bridge
+		}
+	}
+	if len(bridges) == 1 {
+		return bridges[0]
+	}
+	return nil
+}
+
+// StartReleaseUtxoReservationJob release utxo reservation job
+func StartReleaseUtxoReservationJob() {
+	if len(getUtxoReservationBridges()) == 0 {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopReleaseUtxoReservation()
+}
+
+func loopReleaseUtxoReservation() {
+	defer mongodb.MgoWaitGroup.Done()
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			return
+		}
+		logWorkerTrace("utxo", "start release utxo reservation job", "loop", loop)
+		doReleaseUtxoReservationJob()
+		logWorkerTrace("utxo", "finish release utxo reservation job", "loop", loop)
+		restInJob(releaseUtxoReservationInterval)
+	}
+}
+
+func doReleaseUtxoReservationJob() {
+	offset := 0
+	for {
+		if utils.IsCleanuping() {
+			return
+		}
+		reservations, err := mongodb.FindUtxoReservations(offset, utxoReservationPageLimit)
+		if err != nil {
+			logWorkerError("utxo", "FindUtxoReservations failed", err, "offset", offset, "limit", utxoReservationPageLimit)
+			return
+		}
+		released := 0
+		for _, res := range reservations {
+			if reason := shouldReleaseUtxoReservation(res); reason != "" {
+				err = mongodb.RemoveUtxoReservation(res.TxID, res.Vout)
+				if err == nil {
+					released++
+					logWorker("utxo", "release utxo reservation", "txid", res.TxID, "vout", res.Vout, "reserver", res.Reserver, "spendTx", res.SpendTx, "reason", reason)
+				}
+			}
+		}
+		if len(reservations) < utxoReservationPageLimit {
+			break
+		}
+		offset += len(reservations) - released
+	}
+}
+
+func shouldReleaseUtxoReservation(res *mongodb.MgoUtxoReservation) (reason string) {
+	if res.ExpireAt < now() {
+		return "expired"
+	}
+	if res.SpendTx == "" {
+		return ""
+	}
+	bridge := getUtxoReservationBridge(res)
+	if bridge == nil {
+		return ""
+	}
+	outspend, err := bridge.GetOutspend(res.TxID, res.Vout)
+	if err != nil || outspend.Spent == nil || !*outspend.Spent || outspend.Txid == nil {
+		return ""
+	}
+	if !strings.EqualFold(*outspend.Txid, res.SpendTx) {
+		return "replaced"
+	}
+	txStatus, err := bridge.GetTransactionStatus(res.SpendTx)
+	if err != nil || txStatus == nil {
+		return ""
+	}
+	if txStatus.Confirmations >= *bridge.GetChainConfig().Confirmations {
+		return "confirmed"
+	}
+	return ""
+}
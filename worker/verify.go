@@ -3,6 +3,7 @@ package worker
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
@@ -108,18 +109,50 @@ func findSwapoutsToVerify() ([]*mongodb.MgoSwap, error) {
 	return mongodb.FindSwapoutsWithStatus(status, septime)
 }
 
+// isInBlacklist check sender, bind address and tx to (intermediary contract)
 func isInBlacklist(swapInfo *tokens.TxSwapInfo) (isBlacked bool, err error) {
-	isBlacked, err = mongodb.QueryBlacklist(swapInfo.From, swapInfo.PairID)
-	if err != nil {
-		return isBlacked, err
+	txTo := getScreenedTxTo(swapInfo.PairID, swapInfo.TxTo)
+	return isAnyInBlacklist(swapInfo.PairID, swapInfo.From, swapInfo.Bind, txTo)
+}
+
+// getScreenedTxTo ignore tx to if it is the token, router or deposit address of the pair,
+// as these are shared by all swaps of the pair and should not block them all.
+func getScreenedTxTo(pairID, txTo string) string {
+	pairCfg := tokens.GetTokenPairConfig(pairID)
+	if pairCfg == nil || txTo == "" {
+		return txTo
+	}
+	for _, tokenCfg := range []*tokens.TokenConfig{pairCfg.SrcToken, pairCfg.DestToken} {
+		if tokenCfg == nil {
+			continue
+		}
+		if strings.EqualFold(txTo, tokenCfg.ContractAddress) ||
+			strings.EqualFold(txTo, tokenCfg.DepositAddress) ||
+			strings.EqualFold(txTo, tokenCfg.DcrmAddress) {
+			return ""
+		}
 	}
-	if !isBlacked && swapInfo.Bind != swapInfo.From {
-		isBlacked, err = mongodb.QueryBlacklist(swapInfo.Bind, swapInfo.PairID)
-		if err != nil {
+	return txTo
+}
+
+// isAnyInBlacklist ignore empty and duplicate (case insensitive) addresses
+func isAnyInBlacklist(pairID string, addresses ...string) (isBlacked bool, err error) {
+	checked := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		key := strings.ToLower(address)
+		if key == "" {
+			continue
+		}
+		if _, exist := checked[key]; exist {
+			continue
+		}
+		checked[key] = struct{}{}
+		isBlacked, err = mongodb.QueryBlacklist(address, pairID)
+		if err != nil || isBlacked {
 			return isBlacked, err
 		}
 	}
-	return isBlacked, nil
+	return false, nil
 }
 
 func processSwapinVerify(swap *mongodb.MgoSwap) (err error) {
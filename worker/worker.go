@@ -36,12 +36,17 @@ func StartWork(isServer bool) {
 	StartUpdateLatestBlockHeightJob()
 	time.Sleep(interval)
 
+	StartActivatePairConfigJob(isServer)
+	time.Sleep(interval)
+
 	if !isServer {
 		StartAcceptSignJob()
 		time.Sleep(interval)
 		AddTokenPairDynamically()
 		time.Sleep(interval)
 		StartReportStatJob()
+		time.Sleep(interval)
+		StartCheckConfigBundleJob()
 		return
 	}
 
@@ -63,5 +68,14 @@ func StartWork(isServer bool) {
 	StartAggregateJob()
 	time.Sleep(interval)
 
+	StartReleaseUtxoReservationJob()
+	time.Sleep(interval)
+
+	StartCheckTrustlineJob()
+	time.Sleep(interval)
+
+	StartNonceManagerJob()
+	time.Sleep(interval)
+
 	StartCheckFailedSwapJob()
 }